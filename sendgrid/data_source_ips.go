@@ -0,0 +1,152 @@
+package sendgrid
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+)
+
+const (
+	keySubuser            = "subuser"
+	keyAssigned           = "assigned"
+	keyPool               = "pool"
+	keyExcludeWhitelabels = "exclude_whitelabels"
+	keyAddresses          = "addresses"
+	keyPools              = "pools"
+	keySubusers           = "subusers"
+	keyWhitelabeled       = "whitelabeled"
+)
+
+func dataSourceIPs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIPsRead,
+
+		Schema: map[string]*schema.Schema{
+			keySubuser: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			keyAssigned: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			keyPool: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			keyWarmup: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			keyExcludeWhitelabels: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyAddresses: &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyIPs: &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyIP: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyPools: &schema.Schema{
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						keySubusers: &schema.Schema{
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						keyRDNS: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyWarmup: &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						keyStartDate: &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						keyWhitelabeled: &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIPsRead(d *schema.ResourceData, m interface{}) error {
+	params := map[string]string{}
+
+	subuser := d.Get(keySubuser).(string)
+	if subuser != "" {
+		params["subuser"] = subuser
+	}
+
+	excludeWhitelabels := d.Get(keyExcludeWhitelabels).(bool)
+	if excludeWhitelabels {
+		params["exclude_whitelabels"] = "true"
+	}
+
+	config := m.(*Config)
+	ips, err := listIPs(config.APIKey, params)
+	if err != nil {
+		return errors.Wrap(err, "failed to list IPs")
+	}
+
+	pool := d.Get(keyPool).(string)
+	assigned, filterAssigned := d.GetOkExists(keyAssigned)
+	warmup, filterWarmup := d.GetOkExists(keyWarmup)
+
+	addresses := make([]interface{}, 0, len(ips))
+	flattened := make([]interface{}, 0, len(ips))
+	for _, ip := range ips {
+		if pool != "" && !sliceContainsString(ip.Pools, pool) {
+			continue
+		} else if filterAssigned && (len(ip.Subusers) > 0) != assigned.(bool) {
+			continue
+		} else if filterWarmup && ip.Warmup != warmup.(bool) {
+			continue
+		}
+
+		var startDate int64
+		if ip.StartDate != nil {
+			startDate = *ip.StartDate
+		}
+
+		addresses = append(addresses, ip.IP)
+		flattened = append(flattened, map[string]interface{}{
+			keyIP:           ip.IP,
+			keyPools:        stringsToInterfaces(ip.Pools),
+			keySubusers:     stringsToInterfaces(ip.Subusers),
+			keyRDNS:         ip.RDNS,
+			keyWarmup:       ip.Warmup,
+			keyStartDate:    startDate,
+			keyWhitelabeled: ip.Whitelabeled,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s:%t:%s:%v:%v", subuser, excludeWhitelabels, pool, assigned, warmup))))
+	d.Set(keyAddresses, addresses)
+	d.Set(keyIPs, flattened)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package sendgrid
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceIPs(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-sg-test-subuser")
+	passDest := createTempFile()
+	defer os.Remove(passDest)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSubuserCreateConfig(username, passDest, false) + `
+data "sendgrid_ips" "test" {
+	subuser  = sendgrid_subuser.test.id
+	assigned = true
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_ips.test", "addresses.#", strconv.Itoa(len(testIPs))),
+					resource.TestCheckResourceAttr("data.sendgrid_ips.test", "ips.#", strconv.Itoa(len(testIPs))),
+					resource.TestCheckResourceAttrSet("data.sendgrid_ips.test", "ips.0.ip"),
+					resource.TestCheckResourceAttrSet("data.sendgrid_ips.test", "ips.0.subusers.#"),
+				),
+				PreventDiskCleanup: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+data "sendgrid_ips" "test" {
+	pool = "%s"
+}`, acctest.RandomWithPrefix("tf-sg-test-missing-pool")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_ips.test", "addresses.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package sendgrid
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+)
+
+const (
+	keyGenerations     = "generations"
+	keyNameRegex       = "name_regex"
+	keyTemplates       = "templates"
+	keyID              = "id"
+	keyIDs             = "ids"
+	keyActiveVersionID = "active_version_id"
+)
+
+func dataSourceTemplates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			keyGenerations: &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{generationDynamic, generationLegacy}, false),
+				},
+			},
+			keyName: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{keyNameRegex},
+			},
+			keyNameRegex: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsValidRegExp,
+				ConflictsWith: []string{keyName},
+			},
+			keyOnBehalfOf: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			keyIDs: &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyTemplates: &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyID: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyName: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyGeneration: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyUpdatedAt: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyActiveVersionID: &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTemplatesRead(d *schema.ResourceData, m interface{}) error {
+	generations := []string{generationDynamic, generationLegacy}
+	if set := d.Get(keyGenerations).(*schema.Set); set.Len() > 0 {
+		generations = generations[:0]
+		for _, g := range set.List() {
+			generations = append(generations, g.(string))
+		}
+		sort.Strings(generations)
+	}
+
+	name := d.Get(keyName).(string)
+	nameRegex := d.Get(keyNameRegex).(string)
+	onBehalfOf := d.Get(keyOnBehalfOf).(string)
+
+	// Validated by the schema
+	re := regexp.MustCompile(nameRegex)
+
+	config := m.(*Config)
+	templates, err := listTemplates(config.APIKey, generations, onBehalfOf)
+	if err != nil {
+		return errors.Wrap(err, "failed to list templates")
+	}
+
+	ids := make([]interface{}, 0, len(templates))
+	flattened := make([]interface{}, 0, len(templates))
+	for _, t := range templates {
+		if name != "" && t.Name != name {
+			continue
+		} else if !re.MatchString(t.Name) {
+			continue
+		}
+
+		var activeVersionID string
+		for _, v := range t.Versions {
+			if v.Active == 1 {
+				activeVersionID = v.ID
+			}
+		}
+
+		ids = append(ids, t.ID)
+		flattened = append(flattened, map[string]interface{}{
+			keyID:              t.ID,
+			keyName:            t.Name,
+			keyGeneration:      t.Generation,
+			keyUpdatedAt:       t.UpdatedAt,
+			keyActiveVersionID: activeVersionID,
+		})
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s:%s:%s:%s", strings.Join(generations, ","), name, nameRegex, onBehalfOf))))
+	d.Set(keyIDs, ids)
+	d.Set(keyTemplates, flattened)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceTemplates(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-template")
+	templateConfig := fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	name = "%s"
+}
+
+resource "sendgrid_template_version" "test" {
+	template_id  = sendgrid_template.test.id
+	name         = "%s"
+	subject      = "Welcome"
+	html_content = "<p>Welcome</p>"
+}`, name, name)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: templateConfig,
+			},
+			{
+				Config: templateConfig + fmt.Sprintf(`
+data "sendgrid_templates" "test" {
+	generations = ["dynamic"]
+	name        = "%s"
+}`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_templates.test", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.sendgrid_templates.test", "templates.0.id", "sendgrid_template.test", "id"),
+					resource.TestCheckResourceAttrPair("data.sendgrid_templates.test", "templates.0.active_version_id", "sendgrid_template_version.test", "id"),
+				),
+			},
+			{
+				Config: templateConfig + fmt.Sprintf(`
+data "sendgrid_templates" "test" {
+	generations = ["legacy"]
+	name_regex  = "^%s$"
+}`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sendgrid_templates.test", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
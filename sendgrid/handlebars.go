@@ -0,0 +1,701 @@
+package sendgrid
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// hbsHelper describes a handlebars helper supported by Sendgrid dynamic
+// templates. Conditional helpers treat missing values as false rather than as
+// an error when rendering against test data.
+type hbsHelper struct {
+	block       bool
+	conditional bool
+}
+
+var hbsHelpers = map[string]hbsHelper{
+	"if":          {block: true, conditional: true},
+	"unless":      {block: true, conditional: true},
+	"each":        {block: true, conditional: true},
+	"with":        {block: true, conditional: true},
+	"and":         {block: true, conditional: true},
+	"or":          {block: true, conditional: true},
+	"equals":      {block: true},
+	"notEquals":   {block: true},
+	"greaterThan": {block: true},
+	"lessThan":    {block: true},
+	"formatDate":  {},
+	"insert":      {conditional: true},
+	"length":      {},
+	"lookup":      {},
+	"log":         {},
+}
+
+// hbsLongCommentEnd matches the end of a {{!-- --}} comment
+var hbsLongCommentEnd = regexp.MustCompile(`--~?}}`)
+
+// hbsBlockParams matches the block parameters of {{#each items as |item index|}}
+var hbsBlockParams = regexp.MustCompile(`^(.*?)\s+as\s+\|([^|]*)\|$`)
+
+const (
+	hbsText = iota
+	hbsMustache
+	hbsBlock
+)
+
+type hbsNode struct {
+	kind   int
+	line   int
+	name   string
+	params []string
+	// blockParams are the names bound by "as |name|" on a block
+	blockParams []string
+	inverted    bool
+	children    []*hbsNode
+	inverse     []*hbsNode
+}
+
+type hbsFrame struct {
+	node      *hbsNode
+	inInverse bool
+	// chained frames are opened by {{else if ...}} and closed with their parent
+	chained bool
+}
+
+// parseHandlebars parses a template into a tree, reporting unbalanced blocks
+// and unknown helpers.
+func parseHandlebars(content string) ([]*hbsNode, error) {
+	root := &hbsNode{kind: hbsBlock}
+	stack := []*hbsFrame{{node: root}}
+
+	appendNode := func(n *hbsNode) {
+		top := stack[len(stack)-1]
+		if top.inInverse {
+			top.node.inverse = append(top.node.inverse, n)
+		} else {
+			top.node.children = append(top.node.children, n)
+		}
+	}
+
+	rest := content
+	line := 1
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			break
+		}
+
+		// \{{ escapes a mustache, which is then output as it is
+		escaped := start > 0 && rest[start-1] == '\\' && (start == 1 || rest[start-2] != '\\')
+
+		line += strings.Count(rest[:start], "\n")
+		rest = rest[start:]
+
+		var tag string
+		var end int
+		switch {
+		case escaped:
+			end = strings.Index(rest, "}}")
+			if end < 0 {
+				end = len(rest) - len("}}")
+			}
+			end += len("}}")
+		case strings.HasPrefix(rest, "{{!--") || strings.HasPrefix(rest, "{{~!--"):
+			// Long comments may contain }} and end with --}} or --~}}
+			loc := hbsLongCommentEnd.FindStringIndex(rest)
+			if loc == nil {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			end = loc[1]
+		case strings.HasPrefix(rest, "{{{"):
+			end = strings.Index(rest, "}}}")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated {{{ expression", line)
+			}
+			tag = "&" + rest[3:end]
+			end += len("}}}")
+		default:
+			end = strings.Index(rest, "}}")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated {{ expression", line)
+			}
+			tag = rest[2:end]
+			end += len("}}")
+		}
+
+		tagLine := line
+		line += strings.Count(rest[:end], "\n")
+		rest = rest[end:]
+
+		tag = strings.TrimSpace(strings.Trim(strings.TrimSpace(tag), "~"))
+		if tag == "" || strings.HasPrefix(tag, "!") {
+			continue
+		}
+
+		switch tag[0] {
+		case '#', '^':
+			inverted := tag[0] == '^'
+			tag = strings.TrimSpace(tag[1:])
+			if inverted && tag == "" {
+				if err := hbsElse(stack, tagLine, nil); err != nil {
+					return nil, err
+				}
+				break
+			}
+
+			tag, blockParams, err := splitHandlebarsBlockParams(tag, tagLine)
+			if err != nil {
+				return nil, err
+			}
+
+			params, err := splitHandlebarsParams(tag, tagLine)
+			if err != nil {
+				return nil, err
+			}
+
+			n := &hbsNode{kind: hbsBlock, line: tagLine, name: params[0], params: params[1:], blockParams: blockParams, inverted: inverted}
+			if !inverted {
+				if helper, ok := hbsHelpers[n.name]; !ok || !helper.block {
+					return nil, fmt.Errorf("line %d: unknown block helper {{#%s}}", tagLine, n.name)
+				}
+			}
+
+			if err := checkHandlebarsSubexpressions(n.params, tagLine); err != nil {
+				return nil, err
+			}
+
+			appendNode(n)
+			stack = append(stack, &hbsFrame{node: n})
+		case '/':
+			name := strings.TrimSpace(tag[1:])
+			for len(stack) > 1 && stack[len(stack)-1].chained {
+				stack = stack[:len(stack)-1]
+			}
+
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("line %d: {{/%s}} does not close any block", tagLine, name)
+			}
+
+			open := stack[len(stack)-1].node
+			if open.name != name {
+				return nil, fmt.Errorf("line %d: {{/%s}} does not match {{#%s}} opened on line %d", tagLine, name, open.name, open.line)
+			}
+
+			stack = stack[:len(stack)-1]
+		case '>':
+			return nil, fmt.Errorf("line %d: partials are not supported by Sendgrid", tagLine)
+		default:
+			if tag == "else" || strings.HasPrefix(tag, "else ") {
+				var chain []string
+				if chainTag := strings.TrimSpace(strings.TrimPrefix(tag, "else")); chainTag != "" {
+					var err error
+					if chain, err = splitHandlebarsParams(chainTag, tagLine); err != nil {
+						return nil, err
+					}
+				}
+
+				if err := hbsElse(stack, tagLine, chain); err != nil {
+					return nil, err
+				}
+
+				if chain != nil {
+					n := stack[len(stack)-1].node.inverse[0]
+					stack = append(stack, &hbsFrame{node: n, chained: true})
+				}
+				break
+			}
+
+			tag = strings.TrimSpace(strings.TrimPrefix(tag, "&"))
+			params, err := splitHandlebarsParams(tag, tagLine)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(params) > 1 {
+				if _, ok := hbsHelpers[params[0]]; !ok {
+					return nil, fmt.Errorf("line %d: unknown helper %q", tagLine, params[0])
+				}
+			}
+
+			if err := checkHandlebarsSubexpressions(params, tagLine); err != nil {
+				return nil, err
+			}
+
+			appendNode(&hbsNode{kind: hbsMustache, line: tagLine, name: params[0], params: params[1:]})
+		}
+	}
+
+	if len(stack) > 1 {
+		for len(stack) > 1 && stack[len(stack)-1].chained {
+			stack = stack[:len(stack)-1]
+		}
+
+		if len(stack) > 1 {
+			open := stack[len(stack)-1].node
+			return nil, fmt.Errorf("line %d: {{#%s}} is never closed", open.line, open.name)
+		}
+	}
+
+	return root.children, nil
+}
+
+// hbsElse switches the innermost block to its inverse section, starting a
+// chained block for {{else if ...}}.
+func hbsElse(stack []*hbsFrame, line int, chain []string) error {
+	top := stack[len(stack)-1]
+	if len(stack) == 1 {
+		return fmt.Errorf("line %d: {{else}} outside of a block", line)
+	}
+
+	if top.inInverse {
+		return fmt.Errorf("line %d: {{#%s}} has more than one {{else}}", line, top.node.name)
+	}
+
+	top.inInverse = true
+	if chain == nil {
+		return nil
+	}
+
+	if helper, ok := hbsHelpers[chain[0]]; !ok || !helper.block {
+		return fmt.Errorf("line %d: unknown block helper {{else %s}}", line, chain[0])
+	}
+
+	top.node.inverse = []*hbsNode{{kind: hbsBlock, line: line, name: chain[0], params: chain[1:]}}
+
+	return checkHandlebarsSubexpressions(chain[1:], line)
+}
+
+func checkHandlebarsSubexpressions(params []string, line int) error {
+	for _, param := range params {
+		param = hbsHashValue(param)
+		if !strings.HasPrefix(param, "(") {
+			continue
+		}
+
+		inner, err := splitHandlebarsParams(param[1:len(param)-1], line)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := hbsHelpers[inner[0]]; !ok {
+			return fmt.Errorf("line %d: unknown helper %q", line, inner[0])
+		}
+
+		if err := checkHandlebarsSubexpressions(inner[1:], line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitHandlebarsBlockParams separates the "as |name|" block parameters from
+// the rest of a block expression.
+func splitHandlebarsBlockParams(expr string, line int) (string, []string, error) {
+	match := hbsBlockParams.FindStringSubmatch(expr)
+	if match == nil {
+		return expr, nil, nil
+	}
+
+	names := strings.Fields(match[2])
+	if len(names) == 0 {
+		return "", nil, fmt.Errorf("line %d: empty block parameters in {{%s}}", line, expr)
+	}
+
+	return match[1], names, nil
+}
+
+// splitHandlebarsParams splits an expression on whitespace, keeping quoted
+// strings and parenthesized subexpressions together.
+func splitHandlebarsParams(expr string, line int) ([]string, error) {
+	var params []string
+	var current strings.Builder
+	var quote rune
+	depth := 0
+
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses in {{%s}}", line, expr)
+			}
+		case depth == 0 && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if current.Len() > 0 {
+				params = append(params, current.String())
+				current.Reset()
+			}
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("line %d: unterminated string in {{%s}}", line, expr)
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses in {{%s}}", line, expr)
+	}
+
+	if current.Len() > 0 {
+		params = append(params, current.String())
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("line %d: empty expression", line)
+	}
+
+	return params, nil
+}
+
+func hbsHashValue(param string) string {
+	if strings.HasPrefix(param, "(") || strings.HasPrefix(param, "\"") || strings.HasPrefix(param, "'") {
+		return param
+	}
+
+	if i := strings.Index(param, "="); i >= 0 {
+		return param[i+1:]
+	}
+
+	return param
+}
+
+// renderHandlebars walks the template the way Sendgrid would render it with
+// data, reporting every variable that data does not provide.
+func renderHandlebars(nodes []*hbsNode, data interface{}) error {
+	r := &hbsRenderer{missing: map[string]int{}}
+	r.walk(nodes, []interface{}{data})
+
+	if len(r.missing) == 0 {
+		return nil
+	}
+
+	missing := make([]string, 0, len(r.missing))
+	for path, line := range r.missing {
+		missing = append(missing, fmt.Sprintf("%s (line %d)", path, line))
+	}
+	sort.Strings(missing)
+
+	return fmt.Errorf("test data is missing %s", strings.Join(missing, ", "))
+}
+
+type hbsRenderer struct {
+	missing map[string]int
+	// bindings holds the block parameters in scope, innermost last
+	bindings []map[string]interface{}
+}
+
+func (r *hbsRenderer) walk(nodes []*hbsNode, stack []interface{}) {
+	for _, n := range nodes {
+		switch n.kind {
+		case hbsMustache:
+			if len(n.params) == 0 {
+				r.value(n.name, n.line, stack, false)
+			} else {
+				r.helper(n.name, n.params, n.line, stack)
+			}
+		case hbsBlock:
+			r.block(n, stack)
+		}
+	}
+}
+
+func (r *hbsRenderer) block(n *hbsNode, stack []interface{}) {
+	if n.inverted {
+		if hbsTruthy(r.value(n.name, n.line, stack, true)) {
+			r.walk(n.inverse, stack)
+		} else {
+			r.walk(n.children, stack)
+		}
+		return
+	}
+
+	args := r.args(n.name, n.params, n.line, stack)
+	var arg interface{}
+	if len(args) > 0 {
+		arg = args[0]
+	}
+
+	var truthy bool
+	switch n.name {
+	case "if":
+		truthy = hbsTruthy(arg)
+	case "unless":
+		truthy = !hbsTruthy(arg)
+	case "with":
+		if hbsTruthy(arg) {
+			r.walkBound(n, append(stack, arg), arg, nil)
+			return
+		}
+	case "each":
+		items, keys := hbsItems(arg)
+		for i, item := range items {
+			r.walkBound(n, append(stack, item), item, keys[i])
+		}
+
+		if len(items) > 0 {
+			return
+		}
+	case "and", "or":
+		truthy = n.name == "and"
+		for _, a := range args {
+			if hbsTruthy(a) != truthy {
+				truthy = !truthy
+				break
+			}
+		}
+	case "equals", "notEquals":
+		truthy = len(args) == 2 && fmt.Sprint(args[0]) == fmt.Sprint(args[1])
+		if n.name == "notEquals" {
+			truthy = !truthy
+		}
+	case "greaterThan", "lessThan":
+		if len(args) == 2 {
+			a, aOK := hbsNumber(args[0])
+			b, bOK := hbsNumber(args[1])
+			truthy = aOK && bOK && ((n.name == "greaterThan" && a > b) || (n.name == "lessThan" && a < b))
+		}
+	}
+
+	if truthy {
+		r.walk(n.children, stack)
+	} else {
+		r.walk(n.inverse, stack)
+	}
+}
+
+// walkBound walks the children of a block with its block parameters bound to
+// the given value and key.
+func (r *hbsRenderer) walkBound(n *hbsNode, stack []interface{}, value, key interface{}) {
+	if len(n.blockParams) == 0 {
+		r.walk(n.children, stack)
+		return
+	}
+
+	bindings := map[string]interface{}{n.blockParams[0]: value}
+	if len(n.blockParams) > 1 {
+		bindings[n.blockParams[1]] = key
+	}
+
+	r.bindings = append(r.bindings, bindings)
+	r.walk(n.children, stack)
+	r.bindings = r.bindings[:len(r.bindings)-1]
+}
+
+// binding resolves the first segment of a path against the block parameters
+// in scope, returning the bound value and the rest of the path.
+func (r *hbsRenderer) binding(path string) (interface{}, string, bool) {
+	name, rest := path, ""
+	if i := strings.IndexAny(path, "./"); i >= 0 {
+		name, rest = path[:i], path[i+1:]
+	}
+
+	for i := len(r.bindings) - 1; i >= 0; i-- {
+		if v, ok := r.bindings[i][name]; ok {
+			return v, rest, true
+		}
+	}
+
+	return nil, "", false
+}
+
+func (r *hbsRenderer) helper(name string, params []string, line int, stack []interface{}) interface{} {
+	args := r.args(name, params, line, stack)
+
+	switch name {
+	case "length":
+		if len(args) > 0 {
+			if v := reflect.ValueOf(args[0]); v.Kind() == reflect.Slice || v.Kind() == reflect.Map || v.Kind() == reflect.String {
+				return float64(v.Len())
+			}
+		}
+		return float64(0)
+	case "lookup":
+		if len(args) == 2 {
+			if m, ok := args[0].(map[string]interface{}); ok {
+				return m[fmt.Sprint(args[1])]
+			}
+		}
+		return nil
+	}
+
+	return ""
+}
+
+func (r *hbsRenderer) args(name string, params []string, line int, stack []interface{}) []interface{} {
+	conditional := hbsHelpers[name].conditional
+
+	args := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		param = hbsHashValue(param)
+		if strings.HasPrefix(param, "(") {
+			inner, _ := splitHandlebarsParams(param[1:len(param)-1], line)
+			args = append(args, r.helper(inner[0], inner[1:], line, stack))
+			continue
+		}
+
+		args = append(args, r.value(param, line, stack, conditional))
+	}
+
+	return args
+}
+
+// value resolves a literal or path against the context stack, recording the
+// path as missing unless it is optional.
+func (r *hbsRenderer) value(path string, line int, stack []interface{}, optional bool) interface{} {
+	if v, ok := hbsLiteral(path); ok {
+		return v
+	}
+
+	if strings.HasPrefix(path, "@") && !strings.HasPrefix(path, "@root") {
+		// @index, @key, @first and @last are provided by #each
+		return ""
+	}
+
+	current, rest, bound := r.binding(path)
+	if !bound {
+		depth := len(stack) - 1
+		rest = path
+		if strings.HasPrefix(rest, "@root") {
+			depth = 0
+			rest = strings.TrimPrefix(strings.TrimPrefix(rest, "@root"), ".")
+		}
+
+		for strings.HasPrefix(rest, "../") {
+			rest = strings.TrimPrefix(rest, "../")
+			if depth > 0 {
+				depth--
+			}
+		}
+
+		if rest == "this" || strings.HasPrefix(rest, "this.") || strings.HasPrefix(rest, "this/") {
+			rest = strings.TrimPrefix(rest, "this")
+		}
+		rest = strings.TrimPrefix(strings.TrimPrefix(rest, "./"), ".")
+		rest = strings.TrimPrefix(rest, "/")
+
+		current = stack[depth]
+	}
+
+	if rest == "" {
+		return current
+	}
+
+	for _, segment := range strings.FieldsFunc(rest, func(r rune) bool { return r == '.' || r == '/' }) {
+		segment = strings.Trim(segment, "[]")
+
+		var found bool
+		switch c := current.(type) {
+		case map[string]interface{}:
+			current, found = c[segment]
+		case []interface{}:
+			if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(c) {
+				current, found = c[i], true
+			}
+		}
+
+		if !found {
+			if !optional {
+				if _, seen := r.missing[path]; !seen {
+					r.missing[path] = line
+				}
+			}
+			return nil
+		}
+	}
+
+	return current
+}
+
+func hbsLiteral(s string) (interface{}, bool) {
+	switch s {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	case "null", "undefined":
+		return nil, true
+	}
+
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+
+	return nil, false
+}
+
+func hbsTruthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case []interface{}:
+		return len(v) > 0
+	}
+
+	return true
+}
+
+// hbsItems returns what #each iterates over, along with the index or key of
+// every item.
+func hbsItems(v interface{}) ([]interface{}, []interface{}) {
+	switch v := v.(type) {
+	case []interface{}:
+		keys := make([]interface{}, 0, len(v))
+		for i := range v {
+			keys = append(keys, float64(i))
+		}
+		return v, keys
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		items := make([]interface{}, 0, len(v))
+		keys := make([]interface{}, 0, len(v))
+		for _, name := range names {
+			items = append(items, v[name])
+			keys = append(keys, name)
+		}
+		return items, keys
+	}
+
+	return nil, nil
+}
+
+func hbsNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+
+	return 0, false
+}
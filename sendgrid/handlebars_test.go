@@ -0,0 +1,93 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseHandlebars(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{content: "<p>Hello {{first_name}}</p>"},
+		{content: "{{#if paid}}Thanks{{else if pending}}Soon{{else}}Pay{{/if}}"},
+		{content: "{{#each items}}{{this.name}} {{formatDate ../date \"MM/DD\"}}{{/each}}"},
+		{content: "{{!-- {{#if}} --}}{{{raw_html}}}{{insert name \"default=Customer\"}}"},
+		{content: "{{#greaterThan (length items) 1}}many{{/greaterThan}}"},
+		{content: "{{^items}}none{{/items}}"},
+		{content: "{{#each items as |item index|}}{{item.name}}{{index}}{{/each}}"},
+		{content: "{{#with user as |u|}}{{u.name}}{{/with}}"},
+		{content: "{{#each items as ||}}{{/each}}", err: "empty block parameters"},
+		{content: "{{~!-- {{#if a}} }} --~}}{{name}}"},
+		{content: "{{!-- }} --}}"},
+		{content: "{{~!-- never closed }}", err: "unterminated comment"},
+		{content: "\\{{uppercase name}} {{name}}"},
+		{content: "\\{{#repeat}}"},
+		{content: "\\\\{{uppercase name}}", err: `unknown helper "uppercase"`},
+		{content: "{{#if a}}\n{{#each b}}", err: "line 2: {{#each}} is never closed"},
+		{content: "{{#if a}}{{/each}}", err: "{{/each}} does not match {{#if}}"},
+		{content: "{{/if}}", err: "does not close any block"},
+		{content: "{{#repeat items}}{{/repeat}}", err: "unknown block helper {{#repeat}}"},
+		{content: "{{uppercase name}}", err: `unknown helper "uppercase"`},
+		{content: "{{#if (upper a)}}{{/if}}", err: `unknown helper "upper"`},
+		{content: "{{#if a}}{{else}}{{else}}{{/if}}", err: "more than one {{else}}"},
+		{content: "{{else}}", err: "outside of a block"},
+		{content: "Hello {{name", err: "unterminated"},
+		{content: "{{> footer}}", err: "partials are not supported"},
+	}
+
+	for _, test := range tests {
+		_, err := parseHandlebars(test.content)
+		if test.err == "" && err != nil {
+			t.Errorf("parseHandlebars(%q) returned unexpected error: %s", test.content, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("parseHandlebars(%q) returned error %v, want %q", test.content, err, test.err)
+		}
+	}
+}
+
+func TestRenderHandlebars(t *testing.T) {
+	tests := []struct {
+		content string
+		data    string
+		err     string
+	}{
+		{content: "Hello {{user.name}}", data: `{"user": {"name": "Jane"}}`},
+		{content: "Hello {{user.name}}", data: `{"user": {}}`, err: "test data is missing user.name (line 1)"},
+		{content: "{{#if user}}{{user.name}}{{/if}}", data: `{}`},
+		{content: "{{#if user}}{{else}}{{guest}}{{/if}}", data: `{}`, err: "guest"},
+		{content: "{{#each items}}{{name}}{{@index}}{{../currency}}{{/each}}", data: `{"items": [{"name": "a"}], "currency": "EUR"}`},
+		{content: "{{#each items}}{{price}}{{/each}}", data: `{"items": [{"name": "a"}]}`, err: "price"},
+		{content: "{{#equals status \"paid\"}}{{receipt}}{{/equals}}", data: `{"status": "open"}`},
+		{content: "{{#equals status \"paid\"}}{{/equals}}", data: `{}`, err: "status"},
+		{content: "{{insert name \"default=Customer\"}}", data: `{}`},
+		{content: "{{#with address}}{{city}}{{/with}}", data: `{"address": {"city": "Berlin"}}`},
+		{content: "{{#with address as |a|}}{{a.city}}{{/with}}", data: `{"address": {"city": "Berlin"}}`},
+		{content: "\\{{missing}} {{!-- {{other}} --}}", data: `{}`},
+		{content: "{{#each items as |item i|}}{{item.name}}{{i}}{{../currency}}{{/each}}", data: `{"items": [{"name": "a"}], "currency": "EUR"}`},
+		{content: "{{#each items as |item|}}{{item.price}}{{/each}}", data: `{"items": [{"name": "a"}]}`, err: "item.price"},
+		{content: "{{#each orders as |order|}}{{#each order.items as |item|}}{{order.id}}{{item.sku}}{{/each}}{{/each}}", data: `{"orders": [{"id": 1, "items": [{"sku": "x"}]}]}`},
+		{content: "{{#each prices as |price currency|}}{{currency}}{{price}}{{/each}}", data: `{"prices": {"EUR": 1, "USD": 2}}`},
+	}
+
+	for _, test := range tests {
+		nodes, err := parseHandlebars(test.content)
+		if err != nil {
+			t.Fatalf("parseHandlebars(%q) returned unexpected error: %s", test.content, err)
+		}
+
+		var data interface{}
+		if err := json.Unmarshal([]byte(test.data), &data); err != nil {
+			t.Fatal(err)
+		}
+
+		err = renderHandlebars(nodes, data)
+		if test.err == "" && err != nil {
+			t.Errorf("renderHandlebars(%q) returned unexpected error: %s", test.content, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("renderHandlebars(%q) returned error %v, want %q", test.content, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const ipsPageSize = 500
+
+type ipAddress struct {
+	IP           string   `json:"ip"`
+	Pools        []string `json:"pools"`
+	Subusers     []string `json:"subusers"`
+	RDNS         string   `json:"rdns"`
+	Warmup       bool     `json:"warmup"`
+	StartDate    *int64   `json:"start_date"`
+	Whitelabeled bool     `json:"whitelabeled"`
+	AssignedAt   *int64   `json:"assigned_at"`
+}
+
+// listIPs pages through /v3/ips with the given query parameters, returning
+// every matching IP address.
+func listIPs(apiKey string, params map[string]string) ([]ipAddress, error) {
+	var ips []ipAddress
+
+	for offset := 0; ; offset += ipsPageSize {
+		request := sendgrid.GetRequest(apiKey, "/v3/ips", sendgridAddress)
+		request.Method = http.MethodGet
+		request.QueryParams = map[string]string{
+			"limit":  strconv.Itoa(ipsPageSize),
+			"offset": strconv.Itoa(offset),
+		}
+
+		for k, v := range params {
+			request.QueryParams[k] = v
+		}
+
+		res, err := doRequest(request, withStatus(http.StatusOK))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query IPs")
+		}
+
+		var page []ipAddress
+		err = json.Unmarshal([]byte(res.Body), &page)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal IP query response")
+		}
+
+		ips = append(ips, page...)
+
+		if len(page) < ipsPageSize {
+			return ips, nil
+		}
+	}
+}
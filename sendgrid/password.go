@@ -0,0 +1,289 @@
+package sendgrid
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+)
+
+const (
+	keyMinUpper     = "min_upper"
+	keyMinLower     = "min_lower"
+	keyMinDigits    = "min_digits"
+	keyMinSymbols   = "min_symbols"
+	keySymbols      = "symbols"
+	keyExcludeChars = "exclude_chars"
+	keyValue        = "value"
+	keySourceFile   = "source_file"
+	keyPasswordHash = "password_hash"
+
+	keyPasswordValue       = keyPassword + ".0." + keyValue
+	keyPasswordSourceFile  = keyPassword + ".0." + keySourceFile
+	keyPasswordDestination = keyPassword + ".0." + keyDestination
+
+	// Sendgrid rejects subuser passwords outside of these bounds
+	minPasswordLength = 8
+	maxPasswordLength = 128
+
+	defaultPasswordLength = 16
+	defaultMinUpper       = 0
+	defaultMinLower       = 1
+	defaultMinDigits      = 1
+	defaultMinSymbols     = 0
+	defaultSymbols        = "!@#$%^&*()-_=+"
+
+	upperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerChars = "abcdefghijklmnopqrstuvwxyz"
+	digitChars = "0123456789"
+)
+
+type passwordPolicy struct {
+	length     int
+	minUpper   int
+	minLower   int
+	minDigits  int
+	minSymbols int
+	symbols    string
+	exclude    string
+}
+
+func defaultPasswordPolicy() passwordPolicy {
+	return passwordPolicy{
+		length:     defaultPasswordLength,
+		minUpper:   defaultMinUpper,
+		minLower:   defaultMinLower,
+		minDigits:  defaultMinDigits,
+		minSymbols: defaultMinSymbols,
+		symbols:    defaultSymbols,
+	}
+}
+
+func passwordPolicyFromConfig(config map[string]interface{}) passwordPolicy {
+	return passwordPolicy{
+		length:     config[keyLength].(int),
+		minUpper:   config[keyMinUpper].(int),
+		minLower:   config[keyMinLower].(int),
+		minDigits:  config[keyMinDigits].(int),
+		minSymbols: config[keyMinSymbols].(int),
+		symbols:    config[keySymbols].(string),
+		exclude:    config[keyExcludeChars].(string),
+	}
+}
+
+// classes returns each character class with excluded characters removed,
+// paired with the minimum number of characters required from it.
+func (p passwordPolicy) classes() ([]string, []int) {
+	filter := func(chars string) string {
+		return strings.Map(func(r rune) rune {
+			if strings.ContainsRune(p.exclude, r) {
+				return -1
+			}
+			return r
+		}, chars)
+	}
+
+	return []string{
+		filter(upperChars),
+		filter(lowerChars),
+		filter(digitChars),
+		filter(p.symbols),
+	}, []int{
+		p.minUpper,
+		p.minLower,
+		p.minDigits,
+		p.minSymbols,
+	}
+}
+
+func (p passwordPolicy) validate() error {
+	if p.length < minPasswordLength || p.length > maxPasswordLength {
+		return fmt.Errorf("%s must be between %d and %d, got: %d", keyLength, minPasswordLength, maxPasswordLength, p.length)
+	}
+
+	for _, r := range p.symbols {
+		if r > 127 || strings.ContainsRune(upperChars+lowerChars+digitChars, r) {
+			return fmt.Errorf("%s may only contain ASCII characters that are not letters or digits", keySymbols)
+		}
+	}
+
+	classes, mins := p.classes()
+	names := []string{keyMinUpper, keyMinLower, keyMinDigits, keyMinSymbols}
+
+	var total, available int
+	for i := range classes {
+		if mins[i] < 0 {
+			return fmt.Errorf("%s must not be negative", names[i])
+		}
+
+		if mins[i] > 0 && classes[i] == "" {
+			return fmt.Errorf("%s is %d, but no characters of that class are allowed", names[i], mins[i])
+		}
+
+		total += mins[i]
+		available += len(classes[i])
+	}
+
+	if total > p.length {
+		return fmt.Errorf("sum of minimum character counts (%d) exceeds %s (%d)", total, keyLength, p.length)
+	}
+
+	if available == 0 {
+		return fmt.Errorf("%s excludes every allowed character", keyExcludeChars)
+	}
+
+	// Sendgrid requires at least one letter and one number
+	if mins[0]+mins[1] == 0 || mins[2] == 0 {
+		return fmt.Errorf("at least one of %s or %s, and %s, must be greater than zero", keyMinUpper, keyMinLower, keyMinDigits)
+	}
+
+	return nil
+}
+
+// randomInt returns a uniformly distributed integer in [0, n).
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(i.Int64()), nil
+}
+
+func genPassword(policy passwordPolicy) ([]byte, error) {
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+
+	classes, mins := policy.classes()
+	password := make([]byte, 0, policy.length)
+
+	pick := func(chars string) error {
+		i, err := randomInt(len(chars))
+		if err != nil {
+			return err
+		}
+
+		password = append(password, chars[i])
+		return nil
+	}
+
+	for i := range classes {
+		for j := 0; j < mins[i]; j++ {
+			if err := pick(classes[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	all := strings.Join(classes, "")
+	for len(password) < policy.length {
+		if err := pick(all); err != nil {
+			return nil, err
+		}
+	}
+
+	// Fisher-Yates shuffle, so the required characters are not all up front
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return password, nil
+}
+
+// suppliedPassword returns the password given through password.value or
+// password.source_file, and false if the password should be generated instead.
+func suppliedPassword(d *schema.ResourceData) ([]byte, bool, error) {
+	if sourceFile := d.Get(keyPasswordSourceFile).(string); sourceFile != "" {
+		password, err := readPasswordFile(sourceFile)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return []byte(password), true, nil
+	}
+
+	if value := d.Get(keyPasswordValue).(string); value != "" {
+		if _, errs := validatePassword(value, keyPasswordValue); len(errs) > 0 {
+			return nil, false, errs[0]
+		}
+
+		return []byte(value), true, nil
+	}
+
+	return nil, false, nil
+}
+
+func readPasswordFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read password source file")
+	}
+
+	// Mounted secrets commonly end with a newline which is not part of the password
+	password := strings.TrimRight(string(data), "\r\n")
+	if _, errs := validatePassword(password, keyPasswordSourceFile); len(errs) > 0 {
+		return "", errs[0]
+	}
+
+	return password, nil
+}
+
+func validatePassword(v interface{}, k string) ([]string, []error) {
+	password := v.(string)
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return nil, []error{fmt.Errorf("%s must be between %d and %d characters", k, minPasswordLength, maxPasswordLength)}
+	}
+
+	if !strings.ContainsAny(password, upperChars+lowerChars) || !strings.ContainsAny(password, digitChars) {
+		return nil, []error{fmt.Errorf("%s must contain at least one letter and one digit", k)}
+	}
+
+	return nil, nil
+}
+
+func hashPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+// passwordGenerationKeys are the password fields which only apply to a
+// generated password.
+func passwordGenerationKeys() []string {
+	keys := []string{keyLength, keyMinUpper, keyMinLower, keyMinDigits, keyMinSymbols, keySymbols, keyExcludeChars}
+	for i, k := range keys {
+		keys[i] = keyPassword + ".0." + k
+	}
+
+	return keys
+}
+
+// suppressPasswordValueDiff compares password.value with password_hash, since
+// the value itself is never kept in state.
+func suppressPasswordValueDiff(k, old, new string, d *schema.ResourceData) bool {
+	return new != "" && hashPassword(new) == d.Get(keyPasswordHash).(string)
+}
+
+// clearPasswordValue removes password.value from state once it has been
+// recorded in password_hash.
+func clearPasswordValue(d *schema.ResourceData) {
+	passConfigList := d.Get(keyPassword).([]interface{})
+	if len(passConfigList) != 1 {
+		return
+	}
+
+	passConfig := passConfigList[0].(map[string]interface{})
+	passConfig[keyValue] = ""
+	d.Set(keyPassword, passConfigList)
+}
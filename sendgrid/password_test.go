@@ -0,0 +1,124 @@
+package sendgrid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy func(p *passwordPolicy)
+		err    string
+	}{
+		{name: "default", policy: func(p *passwordPolicy) {}},
+		{name: "too short", policy: func(p *passwordPolicy) { p.length = 7 }, err: "length must be between 8 and 128"},
+		{name: "too long", policy: func(p *passwordPolicy) { p.length = 129 }, err: "length must be between 8 and 128"},
+		{name: "letter symbol", policy: func(p *passwordPolicy) { p.symbols = "!a" }, err: "symbols may only contain"},
+		{name: "non-ASCII symbol", policy: func(p *passwordPolicy) { p.symbols = "!é" }, err: "symbols may only contain"},
+		{name: "negative minimum", policy: func(p *passwordPolicy) { p.minUpper = -1 }, err: "min_upper must not be negative"},
+		{name: "minimums exceed length", policy: func(p *passwordPolicy) { p.length = 8; p.minUpper = 4; p.minLower = 4; p.minDigits = 1 }, err: "sum of minimum character counts (9) exceeds length (8)"},
+		{name: "minimums equal length", policy: func(p *passwordPolicy) { p.length = 8; p.minUpper = 3; p.minLower = 4; p.minDigits = 1 }},
+		{name: "excluded class", policy: func(p *passwordPolicy) { p.minDigits = 1; p.exclude = digitChars }, err: "min_digits is 1, but no characters of that class are allowed"},
+		{name: "no symbols allowed", policy: func(p *passwordPolicy) { p.minSymbols = 1; p.symbols = "" }, err: "min_symbols is 1"},
+		{name: "no letters required", policy: func(p *passwordPolicy) { p.minLower = 0 }, err: "must be greater than zero"},
+		{name: "no digits required", policy: func(p *passwordPolicy) { p.minDigits = 0 }, err: "must be greater than zero"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := defaultPasswordPolicy()
+			test.policy(&policy)
+
+			err := policy.validate()
+			if test.err == "" && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Errorf("got error %v, want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestGenPassword(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy passwordPolicy
+	}{
+		{name: "default", policy: defaultPasswordPolicy()},
+		{name: "every class", policy: passwordPolicy{length: 32, minUpper: 4, minLower: 4, minDigits: 4, minSymbols: 4, symbols: defaultSymbols}},
+		{name: "custom symbols", policy: passwordPolicy{length: 20, minLower: 1, minDigits: 1, minSymbols: 10, symbols: "#~"}},
+		{name: "excluded characters", policy: passwordPolicy{length: 64, minUpper: 5, minLower: 5, minDigits: 5, minSymbols: 5, symbols: defaultSymbols, exclude: "0O1lI!"}},
+		{name: "minimums fill length", policy: passwordPolicy{length: 8, minUpper: 3, minLower: 4, minDigits: 1}},
+	}
+
+	count := func(password []byte, chars string) int {
+		var n int
+		for _, c := range password {
+			if strings.IndexByte(chars, c) >= 0 {
+				n++
+			}
+		}
+		return n
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := test.policy
+			for i := 0; i < 50; i++ {
+				password, err := genPassword(p)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if len(password) != p.length {
+					t.Fatalf("got length %d, want %d", len(password), p.length)
+				}
+
+				for j, c := range []struct {
+					chars string
+					min   int
+				}{{upperChars, p.minUpper}, {lowerChars, p.minLower}, {digitChars, p.minDigits}, {p.symbols, p.minSymbols}} {
+					if n := count(password, c.chars); n < c.min {
+						t.Fatalf("%q has %d characters of class %d, want at least %d", password, n, j, c.min)
+					}
+				}
+
+				if n := count(password, upperChars+lowerChars+digitChars+p.symbols); n != len(password) {
+					t.Fatalf("%q contains characters outside of the allowed classes", password)
+				}
+
+				if p.exclude != "" && strings.ContainsAny(string(password), p.exclude) {
+					t.Fatalf("%q contains excluded characters %q", password, p.exclude)
+				}
+			}
+		})
+	}
+}
+
+func TestGenPasswordRejectsInvalidPolicy(t *testing.T) {
+	policy := passwordPolicy{length: 8, minUpper: 5, minLower: 5, minDigits: 1}
+	if _, err := genPassword(policy); err == nil {
+		t.Error("expected an error for minimums exceeding the length")
+	}
+}
+
+func TestGenPasswordShuffles(t *testing.T) {
+	// The single required digit is generated last, so without the shuffle it
+	// would always be the final character.
+	policy := passwordPolicy{length: 8, minLower: 7, minDigits: 1}
+
+	positions := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		password, err := genPassword(policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		positions[strings.IndexAny(string(password), digitChars)] = true
+	}
+
+	if len(positions) < 4 {
+		t.Errorf("digit appeared in only %d distinct positions", len(positions))
+	}
+}
@@ -25,9 +25,32 @@ func Provider() *schema.Provider {
 				Description: "The API key used for Sendgrid Authorization.",
 			},
 		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"sendgrid_ips":       dataSourceIPs(),
+			"sendgrid_templates": dataSourceTemplates(),
+		},
 		ResourcesMap: map[string]*schema.Resource{
-			"sendgrid_subuser": resourceSubuser(),
-			"sendgrid_api_key": resourceAPIKey(),
+			"sendgrid_subuser":                          resourceSubuser(),
+			"sendgrid_teammate":                         resourceTeammate(),
+			"sendgrid_template":                         resourceTemplate(),
+			"sendgrid_template_promotion":               resourceTemplatePromotion(),
+			"sendgrid_template_version":                 resourceTemplateVersion(),
+			"sendgrid_unsubscribe_group":                resourceUnsubscribeGroup(),
+			"sendgrid_api_key":                          resourceAPIKey(),
+			"sendgrid_domain_authentication":            resourceDomainAuthentication(),
+			"sendgrid_domain_authentication_validation": resourceDomainAuthenticationValidation(),
+			"sendgrid_event_webhook":                    resourceEventWebhook(),
+			"sendgrid_event_webhook_test_trigger":       resourceEventWebhookTestTrigger(),
+			"sendgrid_inbound_parse":                    resourceInboundParse(),
+			"sendgrid_ip_access_management":             resourceIPAccessManagement(),
+			"sendgrid_link_branding":                    resourceLinkBranding(),
+			"sendgrid_ip_pool":                          resourceIPPool(),
+			"sendgrid_ip_pool_membership":               resourceIPPoolMembership(),
+			"sendgrid_ip_warmup":                        resourceIPWarmup(),
+			"sendgrid_reverse_dns":                      resourceReverseDNS(),
+			"sendgrid_sso_certificate":                  resourceSSOCertificate(),
+			"sendgrid_sso_integration":                  resourceSSOIntegration(),
+			"sendgrid_sso_teammate":                     resourceSSOTeammate(),
 		},
 	}
 
@@ -59,6 +59,13 @@ func withRateLimit(ch <-chan time.Time) requestOption {
 	}
 }
 
+// setOnBehalfOf makes the request act on behalf of the given subuser, if any.
+func setOnBehalfOf(request rest.Request, onBehalfOf string) {
+	if onBehalfOf != "" {
+		request.Headers[headerOnBehalfOf] = onBehalfOf
+	}
+}
+
 func doRequest(request rest.Request, opts ...requestOption) (res *rest.Response, err error) {
 	o := &requestOpts{
 		backoffDuration: defaultBackoff,
@@ -122,6 +129,25 @@ func sliceContainsInt(slice []int, i int) bool {
 	return false
 }
 
+func sliceContainsString(slice []string, s string) bool {
+	for _, ss := range slice {
+		if ss == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringsToInterfaces(slice []string) []interface{} {
+	result := make([]interface{}, 0, len(slice))
+	for _, s := range slice {
+		result = append(result, s)
+	}
+
+	return result
+}
+
 func sliceContentsAreEqual(a, b []interface{}) bool {
 	if len(a) != len(b) {
 		return false
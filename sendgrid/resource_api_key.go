@@ -66,12 +66,7 @@ func resourceAPIKey() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				MinItems: 1,
 			},
-			keyOnBehalfOf: &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
-				ForceNew: true,
-			},
+			keyOnBehalfOf: onBehalfOfSchema(),
 			keyDestination: &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -97,9 +92,7 @@ func resourceAPIKeyCreate(d *schema.ResourceData, m interface{}) error {
 	request.Method = http.MethodPost
 	request.Body = data
 
-	if onBehalfOf := d.Get(keyOnBehalfOf).(string); onBehalfOf != "" {
-		request.Headers[headerOnBehalfOf] = onBehalfOf
-	}
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
 
 	res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(createAPIKeyRate))
 	if err != nil {
@@ -154,9 +147,7 @@ func resourceAPIKeyUpdate(d *schema.ResourceData, m interface{}) error {
 	request.Method = http.MethodPut
 	request.Body = data
 
-	if onBehalfOf := d.Get(keyOnBehalfOf).(string); onBehalfOf != "" {
-		request.Headers[headerOnBehalfOf] = onBehalfOf
-	}
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
 
 	_, err = doRequest(request, withStatus(http.StatusOK))
 	if err != nil {
@@ -171,9 +162,7 @@ func resourceAPIKeyDelete(d *schema.ResourceData, m interface{}) error {
 	request := sendgrid.GetRequest(config.APIKey, "/v3/api_keys/"+d.Id(), sendgridAddress)
 	request.Method = http.MethodDelete
 
-	if onBehalfOf := d.Get(keyOnBehalfOf).(string); onBehalfOf != "" {
-		request.Headers[headerOnBehalfOf] = onBehalfOf
-	}
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
 
 	res, err := doRequest(request, withStatus(http.StatusNoContent), withRateLimit(deleteAPIKeyRate), withRetry(5))
 	if err == nil || res.StatusCode == http.StatusNotFound {
@@ -189,9 +178,7 @@ func getAPIKey(sendgridAPIKey, id, onBehalfOf string) (*apiKey, error) {
 
 	log.Println("[TRACE] GET /v3/api_keys/" + id)
 
-	if onBehalfOf != "" {
-		request.Headers[headerOnBehalfOf] = onBehalfOf
-	}
+	setOnBehalfOf(request, onBehalfOf)
 
 	// Sendgrid can return a 200 even if not found; but the response body contains
 	// 	{
@@ -277,3 +264,28 @@ func parseAPIKeyImportID(id string) (string, string, string, error) {
 
 	return realID, apiKeyDestination, onBehalfOf, nil
 }
+
+// parseOnBehalfOfImportID splits import IDs of the form id:on_behalf_of, where
+// on_behalf_of may be omitted.
+func parseOnBehalfOfImportID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected id:on_behalf_of", id)
+	}
+
+	if len(parts) == 1 {
+		return parts[0], "", nil
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func onBehalfOfSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
+		ForceNew: true,
+	}
+}
@@ -0,0 +1,267 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keySubdomain          = "subdomain"
+	keyCustomDKIMSelector = "custom_dkim_selector"
+	keyAutomaticSecurity  = "automatic_security"
+	keyDefault            = "default"
+	keyValid              = "valid"
+	keyDNS                = "dns"
+	keyType               = "type"
+	keyHost               = "host"
+	keyData               = "data"
+)
+
+var (
+	createDomainAuthenticationRate = time.Tick(5 * time.Second)
+)
+
+type dnsRecord struct {
+	Valid bool   `json:"valid"`
+	Type  string `json:"type"`
+	Host  string `json:"host"`
+	Data  string `json:"data"`
+}
+
+type domainAuthentication struct {
+	ID                int64                `json:"id"`
+	Domain            string               `json:"domain"`
+	Subdomain         string               `json:"subdomain"`
+	Default           bool                 `json:"default"`
+	AutomaticSecurity bool                 `json:"automatic_security"`
+	Valid             bool                 `json:"valid"`
+	DNS               map[string]dnsRecord `json:"dns"`
+}
+
+func resourceDomainAuthentication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDomainAuthenticationCreate,
+		Read:   resourceDomainAuthenticationRead,
+		Update: resourceDomainAuthenticationUpdate,
+		Delete: resourceDomainAuthenticationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyDomain: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keySubdomain: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			keyCustomDKIMSelector: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			keyAutomaticSecurity: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+			keyDefault: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyValid: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			keyDNS: dnsRecordsSchema(),
+		},
+	}
+}
+
+// dnsRecordsSchema describes the DNS records Sendgrid requires to be published
+// for a whitelabel, keyed by name (e.g. mail_cname or dkim1).
+func dnsRecordsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				keyName: &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				keyType: &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				keyHost: &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				keyData: &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				keyValid: &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceDomainAuthenticationCreate(d *schema.ResourceData, m interface{}) error {
+	payload := map[string]interface{}{
+		"domain":             d.Get(keyDomain).(string),
+		"default":            d.Get(keyDefault).(bool),
+		"automatic_security": d.Get(keyAutomaticSecurity).(bool),
+	}
+
+	if subdomain := d.Get(keySubdomain).(string); subdomain != "" {
+		payload["subdomain"] = subdomain
+	}
+
+	if selector := d.Get(keyCustomDKIMSelector).(string); selector != "" {
+		payload["custom_dkim_selector"] = selector
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/domains", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(createDomainAuthenticationRate))
+	if err != nil {
+		return errors.Wrap(err, "failed to create domain authentication")
+	}
+
+	var domain domainAuthentication
+	err = json.Unmarshal([]byte(res.Body), &domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created domain authentication")
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	return resourceDomainAuthenticationRead(d, m)
+}
+
+func resourceDomainAuthenticationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	domain, err := getDomainAuthentication(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get domain authentication")
+	} else if domain == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyDomain, domain.Domain)
+	d.Set(keySubdomain, domain.Subdomain)
+	d.Set(keyDefault, domain.Default)
+	d.Set(keyAutomaticSecurity, domain.AutomaticSecurity)
+	d.Set(keyValid, domain.Valid)
+	d.Set(keyDNS, flattenDNSRecords(domain.DNS))
+
+	return nil
+}
+
+func resourceDomainAuthenticationUpdate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"default": d.Get(keyDefault).(bool),
+	})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/domains/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update domain authentication")
+	}
+
+	return resourceDomainAuthenticationRead(d, m)
+}
+
+func resourceDomainAuthenticationDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/domains/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete domain authentication")
+}
+
+func getDomainAuthentication(apiKey, id string) (*domainAuthentication, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/domains/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query domain authentication")
+	}
+
+	var domain domainAuthentication
+	err = json.Unmarshal([]byte(res.Body), &domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal domain authentication query response")
+	}
+
+	return &domain, nil
+}
+
+func flattenDNSRecords(records map[string]dnsRecord) []interface{} {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+
+	// Sort by name so that the list has a stable order in state
+	sort.Strings(names)
+
+	result := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		record := records[name]
+		result = append(result, map[string]interface{}{
+			keyName:  name,
+			keyType:  record.Type,
+			keyHost:  record.Host,
+			keyData:  record.Data,
+			keyValid: record.Valid,
+		})
+	}
+
+	return result
+}
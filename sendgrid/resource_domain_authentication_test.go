@@ -0,0 +1,89 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceDomainAuthentication(t *testing.T) {
+	domain := acctest.RandomWithPrefix("tf-sg-test") + ".example.org"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceDomainAuthenticationConfig(domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceDomainAuthenticationCheckSendgrid("sendgrid_domain_authentication.test"),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "domain", domain),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "subdomain", "em"),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "default", "false"),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "automatic_security", "true"),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "valid", "false"),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "dns.#", "3"),
+				),
+			},
+			{
+				Config: testResourceDomainAuthenticationConfig(domain, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceDomainAuthenticationCheckSendgrid("sendgrid_domain_authentication.test"),
+					resource.TestCheckResourceAttr("sendgrid_domain_authentication.test", "default", "true"),
+				),
+			},
+			{
+				ResourceName:            "sendgrid_domain_authentication.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"custom_dkim_selector"},
+			},
+		},
+	})
+}
+
+func testResourceDomainAuthenticationConfig(domain string, isDefault bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_domain_authentication" "test" {
+	domain    = "%s"
+	subdomain = "em"
+	default   = %t
+}`, domain, isDefault)
+}
+
+func testResourceDomainAuthenticationCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		domain, err := getDomainAuthentication(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading domain authentication: %w", err)
+		}
+
+		if domain == nil {
+			return fmt.Errorf("domain authentication not found")
+		}
+
+		if domain.Domain != instanceState.Attributes[keyDomain] {
+			return fmt.Errorf("domain.Domain does not match")
+		}
+
+		if fmt.Sprintf("%t", domain.Default) != instanceState.Attributes[keyDefault] {
+			return fmt.Errorf("domain.Default does not match")
+		}
+
+		return nil
+	}
+}
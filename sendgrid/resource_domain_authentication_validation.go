@@ -0,0 +1,245 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyDomainAuthenticationID = "domain_authentication_id"
+	keyValidationResults      = "validation_results"
+	keyReason                 = "reason"
+
+	defaultValidationTimeout = 10 * time.Minute
+)
+
+type validationResult struct {
+	Valid  bool    `json:"valid"`
+	Reason *string `json:"reason"`
+}
+
+type whitelabelValidation struct {
+	ID                int64                       `json:"id"`
+	Valid             bool                        `json:"valid"`
+	ValidationResults map[string]validationResult `json:"validation_results"`
+}
+
+func resourceDomainAuthenticationValidation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDomainAuthenticationValidationCreate,
+		Read:   resourceDomainAuthenticationValidationRead,
+		Delete: resourceDomainAuthenticationValidationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultValidationTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyDomainAuthenticationID: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDomainID,
+			},
+			keyValid: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			keyValidationResults: validationResultsSchema(),
+		},
+	}
+}
+
+func validationResultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				keyName: &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				keyValid: &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				keyReason: &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceDomainAuthenticationValidationCreate(d *schema.ResourceData, m interface{}) error {
+	id := d.Get(keyDomainAuthenticationID).(string)
+	uri := "/v3/whitelabel/domains/" + id + "/validate"
+
+	// Track the resource before waiting so that the results of a failed
+	// validation are kept in state; the resource is tainted and retried.
+	d.SetId(id)
+
+	validation, err := waitForWhitelabelValidation(m, uri, d.Timeout(schema.TimeoutCreate))
+	if validation != nil {
+		d.Set(keyValidationResults, flattenValidationResults(validation.ValidationResults))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for domain authentication (%s) to be validated: %s", id, err)
+	}
+
+	return resourceDomainAuthenticationValidationRead(d, m)
+}
+
+func resourceDomainAuthenticationValidationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	domain, err := getDomainAuthentication(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get domain authentication")
+	} else if domain == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyDomainAuthenticationID, d.Id())
+	d.Set(keyValid, domain.Valid)
+	d.Set(keyValidationResults, flattenValidationResults(dnsValidationResults(domain.DNS, d.Get(keyValidationResults).([]interface{}))))
+
+	return nil
+}
+
+func resourceDomainAuthenticationValidationDelete(d *schema.ResourceData, m interface{}) error {
+	// Validation can't be undone; forgetting it is all there is to do.
+	return nil
+}
+
+func validateWhitelabel(apiKey, uri string) (*whitelabelValidation, error) {
+	request := sendgrid.GetRequest(apiKey, uri, sendgridAddress)
+	request.Method = http.MethodPost
+
+	res, err := doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to validate")
+	}
+
+	var validation whitelabelValidation
+	err = json.Unmarshal([]byte(res.Body), &validation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal validation response")
+	}
+
+	return &validation, nil
+}
+
+// waitForWhitelabelValidation repeatedly asks Sendgrid to validate the DNS
+// records of a whitelabel until they are valid, returning the last result.
+func waitForWhitelabelValidation(m interface{}, uri string, timeout time.Duration) (*whitelabelValidation, error) {
+	config := m.(*Config)
+
+	var last *whitelabelValidation
+
+	createStateConf := &resource.StateChangeConf{
+		Pending:    []string{statusWaiting},
+		Target:     []string{statusDone},
+		Timeout:    timeout,
+		MinTimeout: defaultBackoff,
+		Refresh: func() (interface{}, string, error) {
+			validation, err := validateWhitelabel(config.APIKey, uri)
+			if l, ok := err.(ratelimitError); ok {
+				time.Sleep(l.timeout)
+				return nil, statusWaiting, nil
+			} else if err != nil {
+				return nil, "", err
+			}
+
+			last = validation
+			if !validation.Valid {
+				return validation, statusWaiting, nil
+			}
+
+			return validation, statusDone, nil
+		},
+	}
+
+	_, err := createStateConf.WaitForState()
+	if err != nil && last != nil {
+		return last, fmt.Errorf("%s (%s)", err, describeValidationFailures(last.ValidationResults))
+	}
+
+	return last, err
+}
+
+func describeValidationFailures(results map[string]validationResult) string {
+	var failures []string
+	for name, result := range results {
+		if !result.Valid && result.Reason != nil {
+			failures = append(failures, name+": "+*result.Reason)
+		}
+	}
+
+	sort.Strings(failures)
+
+	return strings.Join(failures, "; ")
+}
+
+// dnsValidationResults refreshes validation results from the validity of the
+// domain's DNS records. Only validating gives reasons, so the reason of the
+// last attempt is kept for records that are still invalid.
+func dnsValidationResults(dns map[string]dnsRecord, previous []interface{}) map[string]validationResult {
+	reasons := map[string]string{}
+	for _, p := range previous {
+		result := p.(map[string]interface{})
+		reasons[result[keyName].(string)] = result[keyReason].(string)
+	}
+
+	results := make(map[string]validationResult, len(dns))
+	for name, record := range dns {
+		result := validationResult{Valid: record.Valid}
+		if reason, ok := reasons[name]; ok && !record.Valid && reason != "" {
+			result.Reason = &reason
+		}
+
+		results[name] = result
+	}
+
+	return results
+}
+
+func flattenValidationResults(results map[string]validationResult) []interface{} {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+
+	// Sort by name so that the list has a stable order in state
+	sort.Strings(names)
+
+	flattened := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		result := results[name]
+
+		var reason string
+		if result.Reason != nil {
+			reason = *result.Reason
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			keyName:   name,
+			keyValid:  result.Valid,
+			keyReason: reason,
+		})
+	}
+
+	return flattened
+}
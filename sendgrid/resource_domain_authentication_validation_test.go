@@ -0,0 +1,63 @@
+package sendgrid
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccResourceDomainAuthenticationValidation(t *testing.T) {
+	domain := acctest.RandomWithPrefix("tf-sg-test") + ".example.org"
+
+	// The DNS records for the test domain are never published, so validation
+	// is expected to time out and report the failing records.
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testResourceDomainAuthenticationValidationConfig(domain),
+				ExpectError: regexp.MustCompile("error waiting for domain authentication \\(\\d+\\) to be validated"),
+			},
+		},
+	})
+}
+
+func TestDNSValidationResults(t *testing.T) {
+	previous := flattenValidationResults(map[string]validationResult{
+		"dkim1":      {Valid: false, Reason: stringPtr("Expected CNAME to match")},
+		"mail_cname": {Valid: false, Reason: stringPtr("Expected CNAME to match")},
+	})
+
+	results := flattenValidationResults(dnsValidationResults(map[string]dnsRecord{
+		"dkim1":      {Valid: false},
+		"mail_cname": {Valid: true},
+	}, previous))
+
+	want := []interface{}{
+		map[string]interface{}{keyName: "dkim1", keyValid: false, keyReason: "Expected CNAME to match"},
+		map[string]interface{}{keyName: "mail_cname", keyValid: true, keyReason: ""},
+	}
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("dnsValidationResults() = %v, want %v", results, want)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func testResourceDomainAuthenticationValidationConfig(domain string) string {
+	return testResourceDomainAuthenticationConfig(domain, false) + `
+resource "sendgrid_domain_authentication_validation" "test" {
+	domain_authentication_id = sendgrid_domain_authentication.test.id
+
+	timeouts {
+		create = "15s"
+	}
+}`
+}
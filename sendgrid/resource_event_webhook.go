@@ -0,0 +1,360 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyURL               = "url"
+	keyFriendlyName      = "friendly_name"
+	keyOAuthClientID     = "oauth_client_id"
+	keyOAuthClientSecret = "oauth_client_secret"
+	keyOAuthTokenURL     = "oauth_token_url"
+	keySigned            = "signed"
+	keyPublicKey         = "public_key"
+)
+
+// eventWebhookEvents are the events a webhook can be notified of, each of which
+// is a boolean attribute named like its JSON field.
+var eventWebhookEvents = []string{
+	"bounce",
+	"click",
+	"deferred",
+	"delivered",
+	"dropped",
+	"group_resubscribe",
+	"group_unsubscribe",
+	"open",
+	"processed",
+	"spam_report",
+	"unsubscribe",
+}
+
+type eventWebhook struct {
+	ID            string          `json:"id"`
+	URL           string          `json:"url"`
+	Enabled       bool            `json:"enabled"`
+	FriendlyName  string          `json:"friendly_name"`
+	OAuthClientID string          `json:"oauth_client_id"`
+	OAuthTokenURL string          `json:"oauth_token_url"`
+	Events        map[string]bool `json:"-"`
+}
+
+func resourceEventWebhook() *schema.Resource {
+	s := map[string]*schema.Schema{
+		keyURL: &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+		keyEnabled: &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		keyFriendlyName: &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		keyOAuthClientID: &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		keyOAuthClientSecret: &schema.Schema{
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		keyOAuthTokenURL: &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+		keySigned: &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		keyOnBehalfOf: onBehalfOfSchema(),
+		keyPublicKey: &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+
+	for _, event := range eventWebhookEvents {
+		s[event] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+	}
+
+	return &schema.Resource{
+		Create: resourceEventWebhookCreate,
+		Read:   resourceEventWebhookRead,
+		Update: resourceEventWebhookUpdate,
+		Delete: resourceEventWebhookDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				realID, onBehalfOf, err := parseOnBehalfOfImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(realID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: s,
+	}
+}
+
+func (w *eventWebhook) UnmarshalJSON(data []byte) error {
+	type plain eventWebhook
+	if err := json.Unmarshal(data, (*plain)(w)); err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	w.Events = map[string]bool{}
+	for _, event := range eventWebhookEvents {
+		w.Events[event], _ = fields[event].(bool)
+	}
+
+	return nil
+}
+
+func eventWebhookPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	// OAuth is only used when all of its settings are given
+	var oauthSet int
+	for _, k := range []string{keyOAuthClientID, keyOAuthClientSecret, keyOAuthTokenURL} {
+		if d.Get(k).(string) != "" {
+			oauthSet++
+		}
+	}
+
+	if oauthSet != 0 && oauthSet != 3 {
+		return nil, fmt.Errorf("%s, %s and %s must be set together", keyOAuthClientID, keyOAuthClientSecret, keyOAuthTokenURL)
+	}
+
+	payload := map[string]interface{}{
+		"url":                 d.Get(keyURL).(string),
+		"enabled":             d.Get(keyEnabled).(bool),
+		"friendly_name":       d.Get(keyFriendlyName).(string),
+		"oauth_client_id":     d.Get(keyOAuthClientID).(string),
+		"oauth_client_secret": d.Get(keyOAuthClientSecret).(string),
+		"oauth_token_url":     d.Get(keyOAuthTokenURL).(string),
+	}
+
+	for _, event := range eventWebhookEvents {
+		payload[event] = d.Get(event).(bool)
+	}
+
+	return payload, nil
+}
+
+func resourceEventWebhookCreate(d *schema.ResourceData, m interface{}) error {
+	payload, err := eventWebhookPayload(d)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/event/settings", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create event webhook")
+	}
+
+	var webhook eventWebhook
+	err = json.Unmarshal([]byte(res.Body), &webhook)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created event webhook")
+	}
+
+	d.SetId(webhook.ID)
+
+	if d.Get(keySigned).(bool) {
+		if err := setEventWebhookSigned(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string), true); err != nil {
+			return err
+		}
+	}
+
+	return resourceEventWebhookRead(d, m)
+}
+
+func resourceEventWebhookRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	webhook, err := getEventWebhook(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get event webhook")
+	} else if webhook == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyURL, webhook.URL)
+	d.Set(keyEnabled, webhook.Enabled)
+	d.Set(keyFriendlyName, webhook.FriendlyName)
+	d.Set(keyOAuthClientID, webhook.OAuthClientID)
+	d.Set(keyOAuthTokenURL, webhook.OAuthTokenURL)
+
+	for event, enabled := range webhook.Events {
+		d.Set(event, enabled)
+	}
+
+	// Sendgrid never returns the client secret, so it is kept as configured
+
+	publicKey, err := getEventWebhookPublicKey(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get event webhook public key")
+	}
+
+	d.Set(keySigned, publicKey != "")
+	d.Set(keyPublicKey, publicKey)
+
+	return nil
+}
+
+func resourceEventWebhookUpdate(d *schema.ResourceData, m interface{}) error {
+	payload, err := eventWebhookPayload(d)
+	if err != nil {
+		return err
+	}
+
+	// Leave the stored secret alone unless it changed
+	if !d.HasChange(keyOAuthClientSecret) {
+		delete(payload, "oauth_client_secret")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/event/settings/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update event webhook")
+	}
+
+	if d.HasChange(keySigned) {
+		if err := setEventWebhookSigned(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string), d.Get(keySigned).(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceEventWebhookRead(d, m)
+}
+
+func resourceEventWebhookDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/event/settings/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete event webhook")
+}
+
+func getEventWebhook(apiKey, id, onBehalfOf string) (*eventWebhook, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/user/webhooks/event/settings/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query event webhook")
+	}
+
+	var webhook eventWebhook
+	err = json.Unmarshal([]byte(res.Body), &webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal event webhook query response")
+	}
+
+	return &webhook, nil
+}
+
+// setEventWebhookSigned enables or disables signature verification, which
+// gives the webhook a new key pair each time it is enabled.
+func setEventWebhookSigned(apiKey, id, onBehalfOf string, signed bool) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"enabled": signed,
+	})
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, "/v3/user/webhooks/event/settings/signed/"+id, sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, onBehalfOf)
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to set event webhook signature verification")
+	}
+
+	return nil
+}
+
+// getEventWebhookPublicKey gets the key to verify signatures with, which is
+// empty when signature verification is disabled.
+func getEventWebhookPublicKey(apiKey, id, onBehalfOf string) (string, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/user/webhooks/event/settings/signed/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to query event webhook public key")
+	}
+
+	var signed struct {
+		PublicKey string `json:"public_key"`
+	}
+	err = json.Unmarshal([]byte(res.Body), &signed)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal event webhook public key query response")
+	}
+
+	return signed.PublicKey, nil
+}
@@ -0,0 +1,113 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceEventWebhook(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-webhook")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceEventWebhookConfig(name, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceEventWebhookCheckSendgrid("sendgrid_event_webhook.test"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "friendly_name", name),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "bounce", "true"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "open", "false"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "signed", "false"),
+				),
+			},
+			{
+				Config: testResourceEventWebhookConfig(name, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceEventWebhookCheckSendgrid("sendgrid_event_webhook.test"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "open", "true"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "signed", "false"),
+				),
+			},
+			{
+				Config: testResourceEventWebhookConfig(name, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceEventWebhookCheckSendgrid("sendgrid_event_webhook.test"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "signed", "true"),
+					resource.TestCheckResourceAttrSet("sendgrid_event_webhook.test", "public_key"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_event_webhook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testResourceEventWebhookConfig(name, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceEventWebhookCheckSendgrid("sendgrid_event_webhook.test"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "signed", "false"),
+					resource.TestCheckResourceAttr("sendgrid_event_webhook.test", "public_key", ""),
+				),
+			},
+		},
+	})
+}
+
+func testResourceEventWebhookConfig(name string, open, signed bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_event_webhook" "test" {
+	url           = "https://example.org/%s"
+	friendly_name = "%s"
+	bounce        = true
+	delivered     = true
+	open          = %t
+	signed        = %t
+}`, name, name, open, signed)
+}
+
+func testResourceEventWebhookCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		webhook, err := getEventWebhook(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading event webhook: %w", err)
+		}
+
+		if webhook == nil {
+			return fmt.Errorf("event webhook not found")
+		}
+
+		for _, event := range eventWebhookEvents {
+			if fmt.Sprintf("%t", webhook.Events[event]) != instanceState.Attributes[event] {
+				return fmt.Errorf("webhook.Events[%s] does not match state", event)
+			}
+		}
+
+		publicKey, err := getEventWebhookPublicKey(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading event webhook public key: %w", err)
+		}
+
+		if publicKey != instanceState.Attributes["public_key"] {
+			return fmt.Errorf("public key does not match state")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyWebhookID = "webhook_id"
+	keyTriggers  = "triggers"
+)
+
+// resourceEventWebhookTestTrigger asks Sendgrid to post a test event to a
+// webhook when it is created. Sendgrid only reports whether the request was
+// accepted, not whether the endpoint received the event.
+func resourceEventWebhookTestTrigger() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEventWebhookTestTriggerCreate,
+		Read:   resourceEventWebhookTestTriggerRead,
+		Delete: resourceEventWebhookTestTriggerDelete,
+
+		Schema: map[string]*schema.Schema{
+			keyWebhookID: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			keyURL: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			keyOAuthClientID: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			keyOAuthClientSecret: &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				ForceNew:  true,
+			},
+			keyOAuthTokenURL: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			keyTriggers: &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+		},
+	}
+}
+
+func resourceEventWebhookTestTriggerCreate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"id":                  d.Get(keyWebhookID).(string),
+		"url":                 d.Get(keyURL).(string),
+		"oauth_client_id":     d.Get(keyOAuthClientID).(string),
+		"oauth_client_secret": d.Get(keyOAuthClientSecret).(string),
+		"oauth_token_url":     d.Get(keyOAuthTokenURL).(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/event/test", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	if _, err := doRequest(request, withStatus(http.StatusNoContent)); err != nil {
+		return errors.Wrap(err, "failed to send test event")
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
+
+func resourceEventWebhookTestTriggerRead(d *schema.ResourceData, m interface{}) error {
+	// The test event is sent once, so there is nothing to read back
+	return nil
+}
+
+func resourceEventWebhookTestTriggerDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
@@ -0,0 +1,59 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceEventWebhookTestTrigger(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-webhook")
+
+	var firstID string
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceEventWebhookTestTriggerConfig(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sendgrid_event_webhook_test_trigger.test", "id"),
+					func(s *terraform.State) error {
+						firstID = s.RootModule().Resources["sendgrid_event_webhook_test_trigger.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Changing a trigger sends the test event again
+				Config: testResourceEventWebhookTestTriggerConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_event_webhook_test_trigger.test", "triggers.open", "true"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["sendgrid_event_webhook_test_trigger.test"].Primary.ID == firstID {
+							return fmt.Errorf("test trigger was not recreated when its triggers changed")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testResourceEventWebhookTestTriggerConfig(name string, open bool) string {
+	return testResourceEventWebhookConfig(name, open, false) + `
+
+resource "sendgrid_event_webhook_test_trigger" "test" {
+	webhook_id = sendgrid_event_webhook.test.id
+	url        = sendgrid_event_webhook.test.url
+
+	triggers = {
+		open = sendgrid_event_webhook.test.open
+	}
+}`
+}
@@ -0,0 +1,210 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyHostname  = "hostname"
+	keySpamCheck = "spam_check"
+	keySendRaw   = "send_raw"
+
+	inboundParseMX = "mx.sendgrid.net"
+)
+
+type inboundParse struct {
+	Hostname  string `json:"hostname"`
+	URL       string `json:"url"`
+	SpamCheck bool   `json:"spam_check"`
+	SendRaw   bool   `json:"send_raw"`
+}
+
+func resourceInboundParse() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInboundParseCreate,
+		Read:   resourceInboundParseRead,
+		Update: resourceInboundParseUpdate,
+		Delete: resourceInboundParseDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyHostname: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyURL: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			keySpamCheck: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keySendRaw: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyDomainAuthenticationID: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			keyDNS: dnsRecordsSchema(),
+		},
+	}
+}
+
+// checkInboundParseDomain makes sure that the hostname belongs to the
+// authenticated domain it is linked to, if any.
+func checkInboundParseDomain(d *schema.ResourceData, m interface{}) error {
+	id := d.Get(keyDomainAuthenticationID).(string)
+	if id == "" {
+		return nil
+	}
+
+	config := m.(*Config)
+	domain, err := getDomainAuthentication(config.APIKey, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get domain authentication")
+	} else if domain == nil {
+		return fmt.Errorf("domain authentication %s not found", id)
+	}
+
+	hostname := strings.ToLower(d.Get(keyHostname).(string))
+	if hostname != domain.Domain && !strings.HasSuffix(hostname, "."+domain.Domain) {
+		return fmt.Errorf("%s %s is not part of the authenticated domain %s", keyHostname, hostname, domain.Domain)
+	}
+
+	return nil
+}
+
+func inboundParsePayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"hostname":   d.Get(keyHostname).(string),
+		"url":        d.Get(keyURL).(string),
+		"spam_check": d.Get(keySpamCheck).(bool),
+		"send_raw":   d.Get(keySendRaw).(bool),
+	}
+}
+
+func resourceInboundParseCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkInboundParseDomain(d, m); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(inboundParsePayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/parse/settings", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create inbound parse setting")
+	}
+
+	// Inbound parse settings are identified by their hostname
+	d.SetId(d.Get(keyHostname).(string))
+
+	return resourceInboundParseRead(d, m)
+}
+
+func resourceInboundParseRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	parse, err := getInboundParse(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get inbound parse setting")
+	} else if parse == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyHostname, parse.Hostname)
+	d.Set(keyURL, parse.URL)
+	d.Set(keySpamCheck, parse.SpamCheck)
+	d.Set(keySendRaw, parse.SendRaw)
+	d.Set(keyDNS, flattenDNSRecords(map[string]dnsRecord{
+		"mx": {Type: "mx", Host: parse.Hostname, Data: inboundParseMX},
+	}))
+
+	return nil
+}
+
+func resourceInboundParseUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := checkInboundParseDomain(d, m); err != nil {
+		return err
+	}
+
+	payload := inboundParsePayload(d)
+	delete(payload, "hostname")
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/parse/settings/"+url.PathEscape(d.Id()), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update inbound parse setting")
+	}
+
+	return resourceInboundParseRead(d, m)
+}
+
+func resourceInboundParseDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/user/webhooks/parse/settings/"+url.PathEscape(d.Id()), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete inbound parse setting")
+}
+
+func getInboundParse(apiKey, hostname string) (*inboundParse, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/user/webhooks/parse/settings/"+url.PathEscape(hostname), sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query inbound parse setting")
+	}
+
+	var parse inboundParse
+	err = json.Unmarshal([]byte(res.Body), &parse)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal inbound parse setting query response")
+	}
+
+	return &parse, nil
+}
@@ -0,0 +1,89 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceInboundParse(t *testing.T) {
+	domain := acctest.RandomWithPrefix("tf-sg-test-parse") + ".example.org"
+	hostname := "parse." + domain
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceInboundParseConfig(domain, hostname, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceInboundParseCheckSendgrid("sendgrid_inbound_parse.test"),
+					resource.TestCheckResourceAttr("sendgrid_inbound_parse.test", "id", hostname),
+					resource.TestCheckResourceAttr("sendgrid_inbound_parse.test", "spam_check", "false"),
+					resource.TestCheckResourceAttr("sendgrid_inbound_parse.test", "dns.0.host", hostname),
+					resource.TestCheckResourceAttr("sendgrid_inbound_parse.test", "dns.0.data", "mx.sendgrid.net"),
+				),
+			},
+			{
+				Config: testResourceInboundParseConfig(domain, hostname, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceInboundParseCheckSendgrid("sendgrid_inbound_parse.test"),
+					resource.TestCheckResourceAttr("sendgrid_inbound_parse.test", "spam_check", "true"),
+				),
+			},
+			{
+				ResourceName:            "sendgrid_inbound_parse.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"domain_authentication_id"},
+			},
+		},
+	})
+}
+
+func testResourceInboundParseConfig(domain, hostname string, spamCheck bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_domain_authentication" "test" {
+	domain = "%s"
+}
+
+resource "sendgrid_inbound_parse" "test" {
+	hostname                 = "%s"
+	url                      = "https://example.org/parse"
+	spam_check               = %t
+	domain_authentication_id = sendgrid_domain_authentication.test.id
+}`, domain, hostname, spamCheck)
+}
+
+func testResourceInboundParseCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		parse, err := getInboundParse(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading inbound parse setting: %w", err)
+		}
+
+		if parse == nil {
+			return fmt.Errorf("inbound parse setting not found")
+		}
+
+		if fmt.Sprintf("%t", parse.SpamCheck) != instanceState.Attributes["spam_check"] {
+			return fmt.Errorf("parse.SpamCheck does not match state")
+		}
+
+		return nil
+	}
+}
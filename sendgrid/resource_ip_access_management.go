@@ -0,0 +1,362 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyForce = "force"
+
+	ipAccessManagementID = "whitelist"
+)
+
+type allowedIP struct {
+	ID int64  `json:"id"`
+	IP string `json:"ip"`
+}
+
+func resourceIPAccessManagement() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIPAccessManagementCreate,
+		Read:   resourceIPAccessManagementRead,
+		Update: resourceIPAccessManagementUpdate,
+		Delete: resourceIPAccessManagementDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set(keyForce, false)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyIPs: &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Set:      hashAllowedIP,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateIPOrCIDR,
+					StateFunc: func(v interface{}) string {
+						return normalizeAllowedIP(v.(string))
+					},
+				},
+			},
+			keyForce: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceIPAccessManagementCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	ips := d.Get(keyIPs).(*schema.Set)
+
+	existing, err := getAllowedIPs(config.APIKey)
+	if err != nil {
+		return err
+	}
+
+	err = checkIPAccessLockout(config.APIKey, ips, d.Get(keyForce).(bool))
+	if err != nil {
+		return err
+	}
+
+	// The allowlist is account-wide; creating the resource takes it over.
+	var toRemove []allowedIP
+	for _, allowed := range existing {
+		if !ips.Contains(allowed.IP) {
+			toRemove = append(toRemove, allowed)
+		}
+	}
+
+	// Add first, so that a failure can't leave the account locked out
+	err = addAllowedIPs(config.APIKey, ips.Difference(allowedIPSet(existing)).List())
+	if err != nil {
+		return err
+	}
+
+	err = removeAllowedIPs(config.APIKey, toRemove)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(ipAccessManagementID)
+
+	return resourceIPAccessManagementRead(d, m)
+}
+
+func resourceIPAccessManagementRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	allowed, err := getAllowedIPs(config.APIKey)
+	if err != nil {
+		return err
+	}
+
+	d.Set(keyIPs, allowedIPSet(allowed))
+
+	return nil
+}
+
+func resourceIPAccessManagementUpdate(d *schema.ResourceData, m interface{}) error {
+	if !d.HasChange(keyIPs) {
+		return resourceIPAccessManagementRead(d, m)
+	}
+
+	config := m.(*Config)
+	ips := d.Get(keyIPs).(*schema.Set)
+
+	err := checkIPAccessLockout(config.APIKey, ips, d.Get(keyForce).(bool))
+	if err != nil {
+		return err
+	}
+
+	oldIPs, _ := d.GetChange(keyIPs)
+	removed := oldIPs.(*schema.Set).Difference(ips)
+
+	existing, err := getAllowedIPs(config.APIKey)
+	if err != nil {
+		return err
+	}
+
+	var toRemove []allowedIP
+	for _, allowed := range existing {
+		if removed.Contains(allowed.IP) {
+			toRemove = append(toRemove, allowed)
+		}
+	}
+
+	// Add first, so that a failure can't leave the account locked out
+	err = addAllowedIPs(config.APIKey, ips.Difference(allowedIPSet(existing)).List())
+	if err != nil {
+		return err
+	}
+
+	err = removeAllowedIPs(config.APIKey, toRemove)
+	if err != nil {
+		return err
+	}
+
+	return resourceIPAccessManagementRead(d, m)
+}
+
+func resourceIPAccessManagementDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	existing, err := getAllowedIPs(config.APIKey)
+	if err != nil {
+		return err
+	}
+
+	// An empty allowlist allows access from anywhere, so this can't lock us out
+	ips := d.Get(keyIPs).(*schema.Set)
+
+	var toRemove []allowedIP
+	for _, allowed := range existing {
+		if ips.Contains(allowed.IP) {
+			toRemove = append(toRemove, allowed)
+		}
+	}
+
+	return removeAllowedIPs(config.APIKey, toRemove)
+}
+
+func getAllowedIPs(apiKey string) ([]allowedIP, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/access_settings/whitelist", sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query IP allowlist")
+	}
+
+	data := struct {
+		Result []allowedIP `json:"result"`
+	}{}
+
+	err = json.Unmarshal([]byte(res.Body), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal IP allowlist query response")
+	}
+
+	return data.Result, nil
+}
+
+func addAllowedIPs(apiKey string, ips []interface{}) error {
+	if len(ips) == 0 {
+		return nil
+	}
+
+	entries := make([]map[string]interface{}, 0, len(ips))
+	for _, ip := range ips {
+		entries = append(entries, map[string]interface{}{"ip": ip})
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"ips": entries})
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, "/v3/access_settings/whitelist", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to add IPs to allowlist")
+	}
+
+	return nil
+}
+
+func removeAllowedIPs(apiKey string, allowed []allowedIP) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(allowed))
+	for _, a := range allowed {
+		ids = append(ids, a.ID)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"ids": ids})
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, "/v3/access_settings/whitelist", sendgridAddress)
+	request.Method = http.MethodDelete
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusNoContent))
+	if err != nil {
+		return errors.Wrap(err, "failed to remove IPs from allowlist")
+	}
+
+	return nil
+}
+
+// getLastAccessIP returns the IP address of the most recent access to the
+// account, or an empty string if there hasn't been any. Sendgrid doesn't say which IP a request came from, so this is only
+// a guess at the provider's address: the latest access may be someone else's.
+func getLastAccessIP(apiKey string) (string, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/access_settings/activity", sendgridAddress)
+	request.Method = http.MethodGet
+	request.QueryParams = map[string]string{"limit": "1"}
+
+	res, err := doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to query access activity")
+	}
+
+	data := struct {
+		Result []struct {
+			IP string `json:"ip"`
+		} `json:"result"`
+	}{}
+
+	err = json.Unmarshal([]byte(res.Body), &data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal access activity query response")
+	}
+
+	if len(data.Result) == 0 || data.Result[0].IP == "" {
+		return "", nil
+	}
+
+	return data.Result[0].IP, nil
+}
+
+// checkIPAccessLockout refuses an allowlist that doesn't cover the most
+// recent access to the account, unless forced. This is a best-effort warning
+// against lockouts rather than a guarantee that the provider stays allowed.
+func checkIPAccessLockout(apiKey string, ips *schema.Set, force bool) error {
+	if force {
+		return nil
+	}
+
+	lastIP, err := getLastAccessIP(apiKey)
+	if err != nil {
+		return errors.Wrapf(err, "unable to determine the most recent IP address to access the account; set %s = true to apply anyway", keyForce)
+	} else if lastIP == "" {
+		log.Printf("[WARN] no access activity on the account yet; applying the IP allowlist without a lockout check")
+		return nil
+	}
+
+	for _, ip := range ips.List() {
+		if ipMatches(ip.(string), lastIP) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the allowlist would not include %s, the most recent IP address to access the account; set %s = true to apply anyway", lastIP, keyForce)
+}
+
+func ipMatches(allowed, ip string) bool {
+	if !strings.Contains(allowed, "/") {
+		return net.ParseIP(allowed).Equal(net.ParseIP(ip))
+	}
+
+	_, network, err := net.ParseCIDR(allowed)
+	if err != nil {
+		return false
+	}
+
+	return network.Contains(net.ParseIP(ip))
+}
+
+func allowedIPSet(allowed []allowedIP) *schema.Set {
+	ips := schema.NewSet(hashAllowedIP, nil)
+	for _, a := range allowed {
+		ips.Add(normalizeAllowedIP(a.IP))
+	}
+
+	return ips
+}
+
+// normalizeAllowedIP strips the prefix length from single-address CIDR
+// blocks, which Sendgrid adds to plain IP addresses.
+func normalizeAllowedIP(value string) string {
+	if !strings.Contains(value, "/") {
+		return value
+	}
+
+	ip, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return value
+	}
+
+	if ones, bits := network.Mask.Size(); ones != bits {
+		return value
+	}
+
+	return ip.String()
+}
+
+func hashAllowedIP(v interface{}) int {
+	return schema.HashString(normalizeAllowedIP(v.(string)))
+}
+
+func validateIPOrCIDR(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+	if strings.Contains(value, "/") {
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return nil, []error{fmt.Errorf("%s must be an IP address or CIDR block, got: %s", k, value)}
+		}
+	} else if net.ParseIP(value) == nil {
+		return nil, []error{fmt.Errorf("%s must be an IP address or CIDR block, got: %s", k, value)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,66 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccResourceIPAccessManagement(t *testing.T) {
+	// 0.0.0.0/0 keeps the test account reachable whatever the allowlist holds
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceIPAccessManagementConfig(t, []string{"0.0.0.0/0"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_ip_access_management.test", "id", ipAccessManagementID),
+					resource.TestCheckResourceAttr("sendgrid_ip_access_management.test", "ips.#", "1"),
+				),
+			},
+			{
+				Config: testResourceIPAccessManagementConfig(t, []string{"0.0.0.0/0", "192.0.2.1"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_ip_access_management.test", "ips.#", "2"),
+				),
+			},
+			{
+				// Sendgrid stores plain IPs as /32 blocks, which mustn't show up as a diff
+				Config:             testResourceIPAccessManagementConfig(t, []string{"0.0.0.0/0", "192.0.2.1"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:             testResourceIPAccessManagementConfig(t, []string{"0.0.0.0/0", "192.0.2.1/32"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:      testResourceIPAccessManagementConfig(t, []string{"192.0.2.1"}),
+				ExpectError: regexp.MustCompile("the allowlist would not include"),
+			},
+			{
+				ResourceName:      "sendgrid_ip_access_management.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     ipAccessManagementID,
+			},
+		},
+	})
+}
+
+func testResourceIPAccessManagementConfig(t *testing.T, ips []string) string {
+	ipsBytes, err := json.Marshal(ips)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fmt.Sprintf(`
+resource "sendgrid_ip_access_management" "test" {
+	ips = %s
+}`, string(ipsBytes))
+}
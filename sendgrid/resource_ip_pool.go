@@ -0,0 +1,144 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+type ipPool struct {
+	Name string `json:"pool_name"`
+	IPs  []struct {
+		IP string `json:"ip"`
+	} `json:"ips"`
+}
+
+func resourceIPPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIPPoolCreate,
+		Read:   resourceIPPoolRead,
+		Update: resourceIPPoolUpdate,
+		Delete: resourceIPPoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyName: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyIPs: &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceIPPoolCreate(d *schema.ResourceData, m interface{}) error {
+	name := d.Get(keyName).(string)
+
+	data, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/pools", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create IP pool")
+	}
+
+	d.SetId(name)
+
+	return resourceIPPoolRead(d, m)
+}
+
+func resourceIPPoolRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	pool, err := getIPPool(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get IP pool")
+	} else if pool == nil {
+		d.SetId("")
+		return nil
+	}
+
+	ips := make([]interface{}, 0, len(pool.IPs))
+	for _, ip := range pool.IPs {
+		ips = append(ips, ip.IP)
+	}
+
+	d.Set(keyName, pool.Name)
+	d.Set(keyIPs, ips)
+
+	return nil
+}
+
+func resourceIPPoolUpdate(d *schema.ResourceData, m interface{}) error {
+	name := d.Get(keyName).(string)
+
+	data, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/pools/"+url.PathEscape(d.Id()), sendgridAddress)
+	request.Method = http.MethodPut
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to rename IP pool")
+	}
+
+	d.SetId(name)
+
+	return resourceIPPoolRead(d, m)
+}
+
+func resourceIPPoolDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/pools/"+url.PathEscape(d.Id()), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete IP pool")
+}
+
+func getIPPool(apiKey, name string) (*ipPool, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/ips/pools/"+url.PathEscape(name), sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query IP pool")
+	}
+
+	var pool ipPool
+	err = json.Unmarshal([]byte(res.Body), &pool)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal IP pool query response")
+	}
+
+	return &pool, nil
+}
@@ -0,0 +1,183 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyPoolName = "pool_name"
+)
+
+func resourceIPPoolMembership() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIPPoolMembershipCreate,
+		Read:   resourceIPPoolMembershipRead,
+		Delete: resourceIPPoolMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyPoolName: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyIP: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+		},
+	}
+}
+
+func resourceIPPoolMembershipCreate(d *schema.ResourceData, m interface{}) error {
+	poolName := d.Get(keyPoolName).(string)
+	ip := d.Get(keyIP).(string)
+
+	data, err := json.Marshal(map[string]string{"ip": ip})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/pools/"+url.PathEscape(poolName)+"/ips", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to add IP to pool")
+	}
+
+	d.SetId(poolName + ":" + ip)
+
+	err = waitForIPPoolMembership(d, m)
+	if err != nil {
+		return err
+	}
+
+	return resourceIPPoolMembershipRead(d, m)
+}
+
+func resourceIPPoolMembershipRead(d *schema.ResourceData, m interface{}) error {
+	poolName, ip, err := parseIPPoolMembershipID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	isMember, err := getIPPoolMembership(config.APIKey, poolName, ip)
+	if err != nil {
+		return errors.Wrap(err, "failed to get IP pool membership")
+	} else if !isMember {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyPoolName, poolName)
+	d.Set(keyIP, ip)
+
+	return nil
+}
+
+func resourceIPPoolMembershipDelete(d *schema.ResourceData, m interface{}) error {
+	poolName, ip, err := parseIPPoolMembershipID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/pools/"+url.PathEscape(poolName)+"/ips/"+ip, sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to remove IP from pool")
+}
+
+// getIPPoolMembership reads the pools of an IP through the same listing used
+// for subuser IPs.
+func getIPPoolMembership(apiKey, poolName, ip string) (bool, error) {
+	ips, err := listIPs(apiKey, map[string]string{"ip": ip})
+	if err != nil {
+		return false, err
+	}
+
+	for _, address := range ips {
+		if address.IP != ip {
+			continue
+		}
+
+		for _, pool := range address.Pools {
+			if pool == poolName {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func waitForIPPoolMembership(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	poolName := d.Get(keyPoolName).(string)
+	ip := d.Get(keyIP).(string)
+
+	createStateConf := &resource.StateChangeConf{
+		Pending:                   []string{statusWaiting},
+		Target:                    []string{statusDone},
+		Timeout:                   d.Timeout(schema.TimeoutCreate),
+		Delay:                     defaultBackoff,
+		MinTimeout:                defaultBackoff,
+		ContinuousTargetOccurence: 3,
+		Refresh: func() (interface{}, string, error) {
+			isMember, err := getIPPoolMembership(config.APIKey, poolName, ip)
+			if l, ok := err.(ratelimitError); ok {
+				time.Sleep(l.timeout)
+				return nil, statusWaiting, nil
+			} else if err != nil {
+				return nil, "", err
+			} else if !isMember {
+				return nil, statusWaiting, nil
+			}
+
+			return isMember, statusDone, nil
+		},
+	}
+
+	_, err := createStateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for IP %s in pool %s to become consistent: %s", ip, poolName, err)
+	}
+
+	return nil
+}
+
+func parseIPPoolMembershipID(id string) (string, string, error) {
+	// Pool names may contain colons, IPv4 addresses may not, which is why
+	// the ip field only accepts IPv4
+	i := strings.LastIndex(id, ":")
+
+	if i <= 0 || i == len(id)-1 {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected pool_name:ip", id)
+	}
+
+	return id[:i], id[i+1:], nil
+}
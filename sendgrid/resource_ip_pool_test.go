@@ -0,0 +1,105 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceIPPool(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-pool")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceIPPoolConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceIPPoolCheckSendgrid("sendgrid_ip_pool.test"),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool.test", "id", name),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool.test", "name", name),
+				),
+			},
+			{
+				Config: testResourceIPPoolConfig(name + "-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceIPPoolCheckSendgrid("sendgrid_ip_pool.test"),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool.test", "id", name+"-renamed"),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool.test", "name", name+"-renamed"),
+				),
+			},
+			{
+				Config: testResourceIPPoolConfig(name) + testResourceIPPoolMembershipConfig(testIPs[0]),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceIPPoolCheckSendgrid("sendgrid_ip_pool.test"),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool_membership.test", "id", name+":"+testIPs[0]),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool_membership.test", "pool_name", name),
+					resource.TestCheckResourceAttr("sendgrid_ip_pool_membership.test", "ip", testIPs[0]),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_ip_pool_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "sendgrid_ip_pool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					// The pool might not list the membership's IP yet
+					"ips",
+				},
+			},
+		},
+	})
+}
+
+func testResourceIPPoolConfig(name string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_ip_pool" "test" {
+	name = "%s"
+}`, name)
+}
+
+func testResourceIPPoolMembershipConfig(ip string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_ip_pool_membership" "test" {
+	pool_name = sendgrid_ip_pool.test.name
+	ip        = "%s"
+}`, ip)
+}
+
+func testResourceIPPoolCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		pool, err := getIPPool(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading IP pool: %w", err)
+		}
+
+		if pool == nil {
+			return fmt.Errorf("IP pool not found")
+		}
+
+		if pool.Name != instanceState.Attributes[keyName] {
+			return fmt.Errorf("pool.Name does not match")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyWarmup    = "warmup"
+	keyStartDate = "start_date"
+)
+
+type ipWarmup struct {
+	IP        string `json:"ip"`
+	StartDate int64  `json:"start_date"`
+}
+
+func resourceIPWarmup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIPWarmupCreate,
+		Read:   resourceIPWarmupRead,
+		Delete: resourceIPWarmupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyIP: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.SingleIP(),
+			},
+			keyWarmup: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			keyStartDate: &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceIPWarmupCreate(d *schema.ResourceData, m interface{}) error {
+	ip := d.Get(keyIP).(string)
+
+	data, err := json.Marshal(map[string]string{"ip": ip})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/warmup", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to start IP warmup")
+	}
+
+	d.SetId(ip)
+
+	return resourceIPWarmupRead(d, m)
+}
+
+func resourceIPWarmupRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	warmup, err := getIPWarmup(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get IP warmup")
+	}
+
+	d.Set(keyIP, d.Id())
+
+	if warmup != nil {
+		d.Set(keyWarmup, true)
+		d.Set(keyStartDate, warmup.StartDate)
+		return nil
+	}
+
+	// Sendgrid takes an IP out of warmup once it has completed, which is not
+	// a reason to warm it up again. Only forget the warmup if the IP is gone.
+	ips, err := listIPs(config.APIKey, map[string]string{"ip": d.Id()})
+	if err != nil {
+		return errors.Wrap(err, "failed to get IP")
+	}
+
+	for _, ip := range ips {
+		if ip.IP == d.Id() {
+			d.Set(keyWarmup, false)
+			return nil
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceIPWarmupDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/ips/warmup/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to stop IP warmup")
+}
+
+func getIPWarmup(apiKey, ip string) (*ipWarmup, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/ips/warmup/"+ip, sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query IP warmup")
+	}
+
+	var warmups []ipWarmup
+	err = json.Unmarshal([]byte(res.Body), &warmups)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal IP warmup query response")
+	}
+
+	for _, warmup := range warmups {
+		if warmup.IP == ip {
+			return &warmup, nil
+		}
+	}
+
+	return nil, nil
+}
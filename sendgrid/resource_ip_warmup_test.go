@@ -0,0 +1,70 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceIPWarmup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceIPWarmupConfig(testIPs[0]),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceIPWarmupCheckSendgrid("sendgrid_ip_warmup.test"),
+					resource.TestCheckResourceAttr("sendgrid_ip_warmup.test", "id", testIPs[0]),
+					resource.TestCheckResourceAttr("sendgrid_ip_warmup.test", "ip", testIPs[0]),
+					resource.TestCheckResourceAttr("sendgrid_ip_warmup.test", "warmup", "true"),
+					resource.TestCheckResourceAttrSet("sendgrid_ip_warmup.test", "start_date"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_ip_warmup.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceIPWarmupConfig(ip string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_ip_warmup" "test" {
+	ip = "%s"
+}`, ip)
+}
+
+func testResourceIPWarmupCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		warmup, err := getIPWarmup(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading IP warmup: %w", err)
+		}
+
+		if warmup == nil {
+			return fmt.Errorf("IP warmup not found")
+		}
+
+		if fmt.Sprintf("%d", warmup.StartDate) != instanceState.Attributes[keyStartDate] {
+			return fmt.Errorf("warmup.StartDate does not match")
+		}
+
+		return nil
+	}
+}
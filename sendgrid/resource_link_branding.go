@@ -0,0 +1,229 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyWaitForValidation = "wait_for_validation"
+)
+
+var (
+	createLinkBrandingRate = time.Tick(5 * time.Second)
+)
+
+type linkBranding struct {
+	ID        int64                `json:"id"`
+	Domain    string               `json:"domain"`
+	Subdomain string               `json:"subdomain"`
+	Default   bool                 `json:"default"`
+	Valid     bool                 `json:"valid"`
+	DNS       map[string]dnsRecord `json:"dns"`
+}
+
+func resourceLinkBranding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLinkBrandingCreate,
+		Read:   resourceLinkBrandingRead,
+		Update: resourceLinkBrandingUpdate,
+		Delete: resourceLinkBrandingDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set(keyWaitForValidation, false)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultValidationTimeout),
+			Update: schema.DefaultTimeout(defaultValidationTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyDomain: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keySubdomain: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			keyDefault: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyWaitForValidation: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyValid: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			keyDNS:               dnsRecordsSchema(),
+			keyValidationResults: validationResultsSchema(),
+		},
+	}
+}
+
+func resourceLinkBrandingCreate(d *schema.ResourceData, m interface{}) error {
+	payload := map[string]interface{}{
+		"domain":  d.Get(keyDomain).(string),
+		"default": d.Get(keyDefault).(bool),
+	}
+
+	if subdomain := d.Get(keySubdomain).(string); subdomain != "" {
+		payload["subdomain"] = subdomain
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/links", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(createLinkBrandingRate))
+	if err != nil {
+		return errors.Wrap(err, "failed to create link branding")
+	}
+
+	var link linkBranding
+	err = json.Unmarshal([]byte(res.Body), &link)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created link branding")
+	}
+
+	d.SetId(strconv.FormatInt(link.ID, 10))
+
+	if d.Get(keyWaitForValidation).(bool) {
+		err = waitForLinkBrandingValidation(d, m, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceLinkBrandingRead(d, m)
+}
+
+func resourceLinkBrandingRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	link, err := getLinkBranding(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get link branding")
+	} else if link == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyDomain, link.Domain)
+	d.Set(keySubdomain, link.Subdomain)
+	d.Set(keyDefault, link.Default)
+	d.Set(keyValid, link.Valid)
+	d.Set(keyDNS, flattenDNSRecords(link.DNS))
+
+	return nil
+}
+
+func resourceLinkBrandingUpdate(d *schema.ResourceData, m interface{}) error {
+	// A failed validation must not save wait_for_validation, so that it is
+	// retried on the next apply.
+	d.Partial(true)
+
+	if d.HasChange(keyDefault) {
+		data, err := json.Marshal(map[string]interface{}{
+			"default": d.Get(keyDefault).(bool),
+		})
+		if err != nil {
+			return err
+		}
+
+		config := m.(*Config)
+		request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/links/"+d.Id(), sendgridAddress)
+		request.Method = http.MethodPatch
+		request.Body = data
+
+		_, err = doRequest(request, withStatus(http.StatusOK))
+		if err != nil {
+			return errors.Wrap(err, "failed to update link branding")
+		}
+
+		d.SetPartial(keyDefault)
+	}
+
+	if d.HasChange(keyWaitForValidation) && d.Get(keyWaitForValidation).(bool) {
+		err := waitForLinkBrandingValidation(d, m, d.Timeout(schema.TimeoutUpdate))
+		d.SetPartial(keyValidationResults)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceLinkBrandingRead(d, m)
+}
+
+func resourceLinkBrandingDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/links/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete link branding")
+}
+
+func getLinkBranding(apiKey, id string) (*linkBranding, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/links/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query link branding")
+	}
+
+	var link linkBranding
+	err = json.Unmarshal([]byte(res.Body), &link)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal link branding query response")
+	}
+
+	return &link, nil
+}
+
+func waitForLinkBrandingValidation(d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	validation, err := waitForWhitelabelValidation(m, "/v3/whitelabel/links/"+d.Id()+"/validate", timeout)
+	if validation != nil {
+		d.Set(keyValidationResults, flattenValidationResults(validation.ValidationResults))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for link branding (%s) to be validated: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceLinkBranding(t *testing.T) {
+	domain := acctest.RandomWithPrefix("tf-sg-test") + ".example.org"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceLinkBrandingConfig(domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceLinkBrandingCheckSendgrid("sendgrid_link_branding.test"),
+					resource.TestCheckResourceAttr("sendgrid_link_branding.test", "domain", domain),
+					resource.TestCheckResourceAttr("sendgrid_link_branding.test", "subdomain", "links"),
+					resource.TestCheckResourceAttr("sendgrid_link_branding.test", "default", "false"),
+					resource.TestCheckResourceAttr("sendgrid_link_branding.test", "valid", "false"),
+					resource.TestCheckResourceAttr("sendgrid_link_branding.test", "dns.#", "2"),
+				),
+			},
+			{
+				Config: testResourceLinkBrandingConfig(domain, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceLinkBrandingCheckSendgrid("sendgrid_link_branding.test"),
+					resource.TestCheckResourceAttr("sendgrid_link_branding.test", "default", "true"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_link_branding.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceLinkBrandingConfig(domain string, isDefault bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_link_branding" "test" {
+	domain    = "%s"
+	subdomain = "links"
+	default   = %t
+}`, domain, isDefault)
+}
+
+func testResourceLinkBrandingCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		link, err := getLinkBranding(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading link branding: %w", err)
+		}
+
+		if link == nil {
+			return fmt.Errorf("link branding not found")
+		}
+
+		if link.Domain != instanceState.Attributes[keyDomain] {
+			return fmt.Errorf("link.Domain does not match")
+		}
+
+		if fmt.Sprintf("%t", link.Default) != instanceState.Attributes[keyDefault] {
+			return fmt.Errorf("link.Default does not match")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyIP   = "ip"
+	keyRDNS = "rdns"
+)
+
+var (
+	createReverseDNSRate = time.Tick(5 * time.Second)
+)
+
+type reverseDNS struct {
+	ID        int64     `json:"id"`
+	IP        string    `json:"ip"`
+	RDNS      string    `json:"rdns"`
+	Domain    string    `json:"domain"`
+	Subdomain string    `json:"subdomain"`
+	Valid     bool      `json:"valid"`
+	ARecord   dnsRecord `json:"a_record"`
+}
+
+func resourceReverseDNS() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceReverseDNSCreate,
+		Read:   resourceReverseDNSRead,
+		Update: resourceReverseDNSUpdate,
+		Delete: resourceReverseDNSDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set(keyWaitForValidation, false)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultValidationTimeout),
+			Update: schema.DefaultTimeout(defaultValidationTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyIP: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.SingleIP(),
+			},
+			keyDomain: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keySubdomain: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			keyWaitForValidation: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyRDNS: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyValid: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			keyDNS:               dnsRecordsSchema(),
+			keyValidationResults: validationResultsSchema(),
+		},
+	}
+}
+
+func resourceReverseDNSCreate(d *schema.ResourceData, m interface{}) error {
+	payload := map[string]interface{}{
+		"ip":     d.Get(keyIP).(string),
+		"domain": d.Get(keyDomain).(string),
+	}
+
+	if subdomain := d.Get(keySubdomain).(string); subdomain != "" {
+		payload["subdomain"] = subdomain
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/ips", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(createReverseDNSRate))
+	if err != nil {
+		return errors.Wrap(err, "failed to create reverse DNS")
+	}
+
+	var rdns reverseDNS
+	err = json.Unmarshal([]byte(res.Body), &rdns)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created reverse DNS")
+	}
+
+	d.SetId(strconv.FormatInt(rdns.ID, 10))
+
+	if d.Get(keyWaitForValidation).(bool) {
+		err = waitForReverseDNSValidation(d, m, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceReverseDNSRead(d, m)
+}
+
+func resourceReverseDNSRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	rdns, err := getReverseDNS(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get reverse DNS")
+	} else if rdns == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyIP, rdns.IP)
+	d.Set(keyDomain, rdns.Domain)
+	d.Set(keySubdomain, rdns.Subdomain)
+	d.Set(keyRDNS, rdns.RDNS)
+	d.Set(keyValid, rdns.Valid)
+	d.Set(keyDNS, flattenDNSRecords(map[string]dnsRecord{"a_record": rdns.ARecord}))
+
+	return nil
+}
+
+func resourceReverseDNSUpdate(d *schema.ResourceData, m interface{}) error {
+	// Everything but wait_for_validation forces a new resource. A failed
+	// validation must not save it, so that it is retried on the next apply.
+	d.Partial(true)
+
+	if d.HasChange(keyWaitForValidation) && d.Get(keyWaitForValidation).(bool) {
+		err := waitForReverseDNSValidation(d, m, d.Timeout(schema.TimeoutUpdate))
+		d.SetPartial(keyValidationResults)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceReverseDNSRead(d, m)
+}
+
+func resourceReverseDNSDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/whitelabel/ips/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete reverse DNS")
+}
+
+func getReverseDNS(apiKey, id string) (*reverseDNS, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/ips/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query reverse DNS")
+	}
+
+	var rdns reverseDNS
+	err = json.Unmarshal([]byte(res.Body), &rdns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal reverse DNS query response")
+	}
+
+	return &rdns, nil
+}
+
+func waitForReverseDNSValidation(d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	validation, err := waitForWhitelabelValidation(m, "/v3/whitelabel/ips/"+d.Id()+"/validate", timeout)
+	if validation != nil {
+		d.Set(keyValidationResults, flattenValidationResults(validation.ValidationResults))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error waiting for reverse DNS (%s) to be validated: %s", d.Id(), err)
+	}
+
+	return nil
+}
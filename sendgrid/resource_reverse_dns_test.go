@@ -0,0 +1,82 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceReverseDNS(t *testing.T) {
+	domain := acctest.RandomWithPrefix("tf-sg-test") + ".example.org"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceReverseDNSConfig(testIPs[0], domain),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceReverseDNSCheckSendgrid("sendgrid_reverse_dns.test"),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "ip", testIPs[0]),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "domain", domain),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "subdomain", "mail"),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "valid", "false"),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "dns.#", "1"),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "dns.0.name", "a_record"),
+					resource.TestCheckResourceAttr("sendgrid_reverse_dns.test", "dns.0.data", testIPs[0]),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_reverse_dns.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceReverseDNSConfig(ip, domain string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_reverse_dns" "test" {
+	ip        = "%s"
+	domain    = "%s"
+	subdomain = "mail"
+}`, ip, domain)
+}
+
+func testResourceReverseDNSCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		rdns, err := getReverseDNS(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading reverse DNS: %w", err)
+		}
+
+		if rdns == nil {
+			return fmt.Errorf("reverse DNS not found")
+		}
+
+		if rdns.IP != instanceState.Attributes[keyIP] {
+			return fmt.Errorf("rdns.IP does not match")
+		}
+
+		if rdns.RDNS != instanceState.Attributes[keyRDNS] {
+			return fmt.Errorf("rdns.RDNS does not match")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyPublicCertificate = "public_certificate"
+	keyIntegrationID     = "integration_id"
+	keyNotBefore         = "not_before"
+	keyNotAfter          = "not_after"
+)
+
+type ssoCertificate struct {
+	ID                int64  `json:"id"`
+	PublicCertificate string `json:"public_certificate"`
+	NotBefore         int64  `json:"not_before"`
+	NotAfter          int64  `json:"not_after"`
+	Enabled           bool   `json:"enabled"`
+	// Sendgrid misspells this field in its responses
+	IntegrationID string `json:"intergration_id"`
+}
+
+func resourceSSOCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSSOCertificateCreate,
+		Read:   resourceSSOCertificateRead,
+		Update: resourceSSOCertificateUpdate,
+		Delete: resourceSSOCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				realID, onBehalfOf, err := parseOnBehalfOfImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(realID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyIntegrationID: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyPublicCertificate: &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressCertificateDiff,
+			},
+			keyEnabled: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keyNotBefore: &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			keyNotAfter: &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ssoCertificatePayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"public_certificate": d.Get(keyPublicCertificate).(string),
+		"enabled":            d.Get(keyEnabled).(bool),
+		"integration_id":     d.Get(keyIntegrationID).(string),
+	}
+}
+
+func resourceSSOCertificateCreate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(ssoCertificatePayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/certificates", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create SSO certificate")
+	}
+
+	var cert ssoCertificate
+	err = json.Unmarshal([]byte(res.Body), &cert)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created SSO certificate")
+	}
+
+	d.SetId(strconv.FormatInt(cert.ID, 10))
+
+	return resourceSSOCertificateRead(d, m)
+}
+
+func resourceSSOCertificateRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cert, err := getSSOCertificate(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get SSO certificate")
+	} else if cert == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyIntegrationID, cert.IntegrationID)
+	d.Set(keyPublicCertificate, cert.PublicCertificate)
+	d.Set(keyEnabled, cert.Enabled)
+	d.Set(keyNotBefore, cert.NotBefore)
+	d.Set(keyNotAfter, cert.NotAfter)
+
+	return nil
+}
+
+func resourceSSOCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(ssoCertificatePayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/certificates/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update SSO certificate")
+	}
+
+	return resourceSSOCertificateRead(d, m)
+}
+
+func resourceSSOCertificateDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/certificates/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete SSO certificate")
+}
+
+func getSSOCertificate(apiKey, id, onBehalfOf string) (*ssoCertificate, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/sso/certificates/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query SSO certificate")
+	}
+
+	var cert ssoCertificate
+	err = json.Unmarshal([]byte(res.Body), &cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal SSO certificate query response")
+	}
+
+	return &cert, nil
+}
+
+// suppressCertificateDiff ignores PEM armour and line wrapping, which Sendgrid
+// may strip from the stored certificate.
+func suppressCertificateDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeCertificate(old) == normalizeCertificate(new)
+}
+
+func normalizeCertificate(cert string) string {
+	cert = strings.Replace(cert, "-----BEGIN CERTIFICATE-----", "", -1)
+	cert = strings.Replace(cert, "-----END CERTIFICATE-----", "", -1)
+
+	return strings.Join(strings.Fields(cert), "")
+}
@@ -0,0 +1,131 @@
+package sendgrid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceSSOCertificate(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-sso")
+	cert := testSelfSignedCertificate(t)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSSOCertificateConfig(name, cert, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSSOCertificateCheckSendgrid("sendgrid_sso_certificate.test"),
+					resource.TestCheckResourceAttrPair("sendgrid_sso_certificate.test", "integration_id", "sendgrid_sso_integration.test", "id"),
+					resource.TestCheckResourceAttr("sendgrid_sso_certificate.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("sendgrid_sso_certificate.test", "not_before"),
+					resource.TestCheckResourceAttrSet("sendgrid_sso_certificate.test", "not_after"),
+				),
+			},
+			{
+				Config: testResourceSSOCertificateConfig(name, cert, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSSOCertificateCheckSendgrid("sendgrid_sso_certificate.test"),
+					resource.TestCheckResourceAttr("sendgrid_sso_certificate.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_sso_certificate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNormalizeCertificate(t *testing.T) {
+	cert := "-----BEGIN CERTIFICATE-----\nMIIB\nAbCd\n-----END CERTIFICATE-----\n"
+	if got := normalizeCertificate(cert); got != "MIIBAbCd" {
+		t.Errorf("normalizeCertificate(%q) = %q, want %q", cert, got, "MIIBAbCd")
+	}
+}
+
+func testSelfSignedCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.org"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testResourceSSOCertificateConfig(name, cert string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_sso_integration" "test" {
+	name        = "%s"
+	signin_url  = "https://idp.example.org/sso/saml"
+	signout_url = "https://idp.example.org/slo/saml"
+	entity_id   = "https://idp.example.org/%s"
+}
+
+resource "sendgrid_sso_certificate" "test" {
+	integration_id     = sendgrid_sso_integration.test.id
+	enabled            = %t
+	public_certificate = <<EOT
+%sEOT
+}`, name, name, enabled, cert)
+}
+
+func testResourceSSOCertificateCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		cert, err := getSSOCertificate(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading SSO certificate: %w", err)
+		}
+
+		if cert == nil {
+			return fmt.Errorf("SSO certificate not found")
+		}
+
+		if cert.IntegrationID != instanceState.Attributes["integration_id"] {
+			return fmt.Errorf("cert.IntegrationID does not match state")
+		}
+
+		if fmt.Sprint(cert.Enabled) != instanceState.Attributes["enabled"] {
+			return fmt.Errorf("cert.Enabled does not match state")
+		}
+
+		return nil
+	}
+}
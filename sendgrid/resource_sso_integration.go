@@ -0,0 +1,211 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyEnabled              = "enabled"
+	keySigninURL            = "signin_url"
+	keySignoutURL           = "signout_url"
+	keyEntityID             = "entity_id"
+	keyCompletedIntegration = "completed_integration"
+	keySingleSignonURL      = "single_signon_url"
+	keyAudienceURL          = "audience_url"
+)
+
+type ssoIntegration struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	Enabled              bool   `json:"enabled"`
+	SigninURL            string `json:"signin_url"`
+	SignoutURL           string `json:"signout_url"`
+	EntityID             string `json:"entity_id"`
+	CompletedIntegration bool   `json:"completed_integration"`
+	SingleSignonURL      string `json:"single_signon_url"`
+	AudienceURL          string `json:"audience_url"`
+}
+
+func resourceSSOIntegration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSSOIntegrationCreate,
+		Read:   resourceSSOIntegrationRead,
+		Update: resourceSSOIntegrationUpdate,
+		Delete: resourceSSOIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				realID, onBehalfOf, err := parseOnBehalfOfImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(realID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyName: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyEnabled: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			keySigninURL: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keySignoutURL: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyEntityID: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyCompletedIntegration: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keySingleSignonURL: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyAudienceURL: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ssoIntegrationPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                  d.Get(keyName).(string),
+		"enabled":               d.Get(keyEnabled).(bool),
+		"signin_url":            d.Get(keySigninURL).(string),
+		"signout_url":           d.Get(keySignoutURL).(string),
+		"entity_id":             d.Get(keyEntityID).(string),
+		"completed_integration": d.Get(keyCompletedIntegration).(bool),
+	}
+}
+
+func resourceSSOIntegrationCreate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(ssoIntegrationPayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/integrations", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create SSO integration")
+	}
+
+	var integration ssoIntegration
+	err = json.Unmarshal([]byte(res.Body), &integration)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created SSO integration")
+	}
+
+	d.SetId(integration.ID)
+
+	return resourceSSOIntegrationRead(d, m)
+}
+
+func resourceSSOIntegrationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	integration, err := getSSOIntegration(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get SSO integration")
+	} else if integration == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyName, integration.Name)
+	d.Set(keyEnabled, integration.Enabled)
+	d.Set(keySigninURL, integration.SigninURL)
+	d.Set(keySignoutURL, integration.SignoutURL)
+	d.Set(keyEntityID, integration.EntityID)
+	d.Set(keyCompletedIntegration, integration.CompletedIntegration)
+	d.Set(keySingleSignonURL, integration.SingleSignonURL)
+	d.Set(keyAudienceURL, integration.AudienceURL)
+
+	return nil
+}
+
+func resourceSSOIntegrationUpdate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(ssoIntegrationPayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/integrations/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update SSO integration")
+	}
+
+	return resourceSSOIntegrationRead(d, m)
+}
+
+func resourceSSOIntegrationDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/integrations/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete SSO integration")
+}
+
+func getSSOIntegration(apiKey, id, onBehalfOf string) (*ssoIntegration, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/sso/integrations/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+	request.QueryParams = map[string]string{"si": "true"}
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query SSO integration")
+	}
+
+	var integration ssoIntegration
+	err = json.Unmarshal([]byte(res.Body), &integration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal SSO integration query response")
+	}
+
+	return &integration, nil
+}
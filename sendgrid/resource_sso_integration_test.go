@@ -0,0 +1,84 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceSSOIntegration(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-sso")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSSOIntegrationConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSSOIntegrationCheckSendgrid("sendgrid_sso_integration.test"),
+					resource.TestCheckResourceAttr("sendgrid_sso_integration.test", "name", name),
+					resource.TestCheckResourceAttr("sendgrid_sso_integration.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("sendgrid_sso_integration.test", "single_signon_url"),
+					resource.TestCheckResourceAttrSet("sendgrid_sso_integration.test", "audience_url"),
+				),
+			},
+			{
+				Config: testResourceSSOIntegrationConfig(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSSOIntegrationCheckSendgrid("sendgrid_sso_integration.test"),
+					resource.TestCheckResourceAttr("sendgrid_sso_integration.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_sso_integration.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceSSOIntegrationConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_sso_integration" "test" {
+	name        = "%s"
+	enabled     = %t
+	signin_url  = "https://idp.example.org/sso/saml"
+	signout_url = "https://idp.example.org/slo/saml"
+	entity_id   = "https://idp.example.org/%s"
+}`, name, enabled, name)
+}
+
+func testResourceSSOIntegrationCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		integration, err := getSSOIntegration(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading SSO integration: %w", err)
+		}
+
+		if integration == nil {
+			return fmt.Errorf("SSO integration not found")
+		}
+
+		if fmt.Sprintf("%t", integration.Enabled) != instanceState.Attributes["enabled"] {
+			return fmt.Errorf("integration.Enabled does not match state")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,294 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyFirstName      = "first_name"
+	keyLastName       = "last_name"
+	keySubuserAccess  = "subuser_access"
+	keySubuserID      = "subuser_id"
+	keyPermissionType = "permission_type"
+	keyPersona        = "persona"
+
+	permissionTypeAdmin      = "admin"
+	permissionTypeRestricted = "restricted"
+)
+
+type ssoTeammate struct {
+	Username      string        `json:"username"`
+	Email         string        `json:"email"`
+	FirstName     string        `json:"first_name"`
+	LastName      string        `json:"last_name"`
+	IsAdmin       bool          `json:"is_admin"`
+	Persona       string        `json:"persona"`
+	Scopes        []interface{} `json:"scopes"`
+	SubuserAccess []struct {
+		ID             int64         `json:"id"`
+		PermissionType string        `json:"permission_type"`
+		Scopes         []interface{} `json:"scopes"`
+	} `json:"subuser_access"`
+}
+
+func resourceSSOTeammate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSSOTeammateCreate,
+		Read:   resourceSSOTeammateRead,
+		Update: resourceSSOTeammateUpdate,
+		Delete: resourceSSOTeammateDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				realID, onBehalfOf, err := parseOnBehalfOfImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(realID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyEmail: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyFirstName: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyLastName: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyIsAdmin: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyPersona: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{keyIsAdmin, keyScopes},
+				ValidateFunc:  validation.StringInSlice([]string{"accountant", "developer", "marketer", "observer"}, false),
+			},
+			keyScopes: &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     teammateScopeSchema(),
+			},
+			keySubuserAccess: &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keySubuserID: &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						keyPermissionType: &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{permissionTypeAdmin, permissionTypeRestricted}, false),
+						},
+						keyScopes: &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     teammateScopeSchema(),
+						},
+					},
+				},
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keyUsername: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ssoTeammatePayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	isAdmin := d.Get(keyIsAdmin).(bool)
+	scopes := d.Get(keyScopes).(*schema.Set).List()
+	subuserAccess := d.Get(keySubuserAccess).(*schema.Set).List()
+
+	if isAdmin && (len(scopes) > 0 || len(subuserAccess) > 0) {
+		return nil, fmt.Errorf("%s can't be combined with %s or %s", keyIsAdmin, keyScopes, keySubuserAccess)
+	}
+
+	access := make([]map[string]interface{}, 0, len(subuserAccess))
+	for _, a := range subuserAccess {
+		a := a.(map[string]interface{})
+		accessScopes := a[keyScopes].(*schema.Set).List()
+
+		if a[keyPermissionType] == permissionTypeRestricted && len(accessScopes) == 0 {
+			return nil, fmt.Errorf("%s for subuser %d is %s but has no %s", keyPermissionType, a[keySubuserID], permissionTypeRestricted, keyScopes)
+		}
+
+		access = append(access, map[string]interface{}{
+			"id":              a[keySubuserID],
+			"permission_type": a[keyPermissionType],
+			"scopes":          accessScopes,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"first_name":                    d.Get(keyFirstName).(string),
+		"last_name":                     d.Get(keyLastName).(string),
+		"is_admin":                      isAdmin,
+		"scopes":                        scopes,
+		"has_restricted_subuser_access": len(access) > 0,
+		"subuser_access":                access,
+	}
+
+	// A persona grants a predefined set of scopes in place of explicit ones
+	if persona := d.Get(keyPersona).(string); persona != "" {
+		payload["persona"] = persona
+		delete(payload, "scopes")
+	}
+
+	return payload, nil
+}
+
+func resourceSSOTeammateCreate(d *schema.ResourceData, m interface{}) error {
+	payload, err := ssoTeammatePayload(d)
+	if err != nil {
+		return err
+	}
+
+	payload["email"] = d.Get(keyEmail).(string)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/teammates", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusCreated), withRateLimit(createTeammateRate))
+	if err != nil {
+		return errors.Wrap(err, "failed to create SSO teammate")
+	}
+
+	// SSO teammates are identified by their email address
+	d.SetId(d.Get(keyEmail).(string))
+
+	return resourceSSOTeammateRead(d, m)
+}
+
+func resourceSSOTeammateRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	mate, err := getSSOTeammate(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get SSO teammate")
+	} else if mate == nil {
+		d.SetId("")
+		return nil
+	}
+
+	access := make([]interface{}, 0, len(mate.SubuserAccess))
+	for _, a := range mate.SubuserAccess {
+		access = append(access, map[string]interface{}{
+			keySubuserID:      int(a.ID),
+			keyPermissionType: a.PermissionType,
+			keyScopes:         schema.NewSet(schema.HashString, a.Scopes),
+		})
+	}
+
+	d.Set(keyEmail, mate.Email)
+	d.Set(keyFirstName, mate.FirstName)
+	d.Set(keyLastName, mate.LastName)
+	d.Set(keyIsAdmin, mate.IsAdmin)
+	d.Set(keyPersona, mate.Persona)
+	d.Set(keyUsername, mate.Username)
+	d.Set(keySubuserAccess, access)
+
+	// Admins implicitly have every scope
+	if mate.IsAdmin {
+		d.Set(keyScopes, nil)
+	} else {
+		d.Set(keyScopes, mate.Scopes)
+	}
+
+	return nil
+}
+
+func resourceSSOTeammateUpdate(d *schema.ResourceData, m interface{}) error {
+	payload, err := ssoTeammatePayload(d)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/sso/teammates/"+url.PathEscape(d.Id()), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update SSO teammate")
+	}
+
+	return resourceSSOTeammateRead(d, m)
+}
+
+func resourceSSOTeammateDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/teammates/"+url.PathEscape(d.Id()), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete SSO teammate")
+}
+
+func getSSOTeammate(apiKey, username, onBehalfOf string) (*ssoTeammate, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/teammates/"+url.PathEscape(username), sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query SSO teammate")
+	}
+
+	var mate ssoTeammate
+	err = json.Unmarshal([]byte(res.Body), &mate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal SSO teammate query response")
+	}
+
+	return &mate, nil
+}
@@ -0,0 +1,82 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceSSOTeammate(t *testing.T) {
+	email := acctest.RandomWithPrefix("tf-sg-test-sso-teammate") + "@example.org"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSSOTeammateConfig(email, "Jane"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSSOTeammateCheckSendgrid("sendgrid_sso_teammate.test"),
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate.test", "id", email),
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate.test", "first_name", "Jane"),
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate.test", "scopes.#", "1"),
+				),
+			},
+			{
+				Config: testResourceSSOTeammateConfig(email, "Janet"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSSOTeammateCheckSendgrid("sendgrid_sso_teammate.test"),
+					resource.TestCheckResourceAttr("sendgrid_sso_teammate.test", "first_name", "Janet"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_sso_teammate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceSSOTeammateConfig(email, firstName string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_sso_teammate" "test" {
+	email      = "%s"
+	first_name = "%s"
+	last_name  = "Doe"
+	scopes     = ["mail.send"]
+}`, email, firstName)
+}
+
+func testResourceSSOTeammateCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		mate, err := getSSOTeammate(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading SSO teammate: %w", err)
+		}
+
+		if mate == nil {
+			return fmt.Errorf("SSO teammate not found")
+		}
+
+		if mate.FirstName != instanceState.Attributes["first_name"] {
+			return fmt.Errorf("mate.FirstName does not match state")
+		}
+
+		return nil
+	}
+}
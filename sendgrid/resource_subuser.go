@@ -2,9 +2,9 @@ package sendgrid
 
 import (
 	"bytes"
-	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/pkg/errors"
 	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go"
@@ -19,17 +20,22 @@ import (
 )
 
 const (
-	keyUsername    = "username"
-	keyEmail       = "email"
-	keyPassword    = "password"
-	keyDestination = "destination"
-	keyLength      = "length"
-	keyDisabled    = "disabled"
-	keyIPs         = "ips"
-	keyDomain      = "domain"
+	keyUsername              = "username"
+	keyEmail                 = "email"
+	keyPassword              = "password"
+	keyDestination           = "destination"
+	keyLength                = "length"
+	keyDisabled              = "disabled"
+	keyWebsiteAccessDisabled = "website_access_disabled"
+	keyIPs                   = "ips"
+	keyDomain                = "domain"
+	keyDomains               = "domains"
+	keyLinkBranding          = "link_branding"
+	keyAdoptExisting         = "adopt_existing"
+	keyResetExisting         = "reset_existing"
 
 	defaultDomainID       = "0"
-	defaultPasswordLength = 16
+	defaultLinkBrandingID = "0"
 )
 
 var (
@@ -49,6 +55,37 @@ func resourceSubuser() *schema.Resource {
 		Read:   resourceSubuserRead,
 		Update: resourceSubuserUpdate,
 		Delete: resourceSubuserDelete,
+		CustomizeDiff: func(d *schema.ResourceDiff, m interface{}) error {
+			// A supplied password can't be replaced by a generated one in
+			// place, which is refused here before anything is applied.
+			oldHash, _ := d.GetChange(keyPasswordHash)
+			sourceFile := d.Get(keyPasswordSourceFile).(string)
+			if d.Id() != "" && oldHash.(string) != "" && !d.HasChange(keyPasswordDestination) &&
+				d.Get(keyPasswordDestination).(string) == "" && d.Get(keyPasswordValue).(string) == "" && sourceFile == "" {
+				return fmt.Errorf("password.%s or password.%s may not be removed; generating a new password requires replacing the subuser", keyValue, keySourceFile)
+			}
+
+			// A changed source file does not change the configuration, so
+			// compare the hash of its contents with the hash in state.
+			if sourceFile == "" {
+				if d.HasChange(keyPasswordValue) {
+					return d.SetNewComputed(keyPasswordHash)
+				}
+
+				return nil
+			}
+
+			password, err := readPasswordFile(sourceFile)
+			if err != nil {
+				return err
+			}
+
+			if hash := hashPassword(password); hash != d.Get(keyPasswordHash).(string) {
+				return d.SetNew(keyPasswordHash, hash)
+			}
+
+			return nil
+		},
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				realID, password, err := parseSubuserImportID(d.Id())
@@ -57,6 +94,8 @@ func resourceSubuser() *schema.Resource {
 				}
 
 				d.Set(keyPassword, password)
+				d.Set(keyAdoptExisting, false)
+				d.Set(keyWebsiteAccessDisabled, false)
 				d.SetId(realID)
 
 				return []*schema.ResourceData{d}, nil
@@ -79,23 +118,92 @@ func resourceSubuser() *schema.Resource {
 				Required: true,
 				MaxItems: 1,
 				MinItems: 1,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						keyDestination: &schema.Schema{
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{keyPasswordValue, keyPasswordSourceFile},
+						},
+						keyValue: &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							ValidateFunc:     validatePassword,
+							DiffSuppressFunc: suppressPasswordValueDiff,
+							ConflictsWith:    append([]string{keyPasswordSourceFile}, passwordGenerationKeys()...),
+						},
+						keySourceFile: &schema.Schema{
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: passwordGenerationKeys(),
+						},
+						keyLength: &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultPasswordLength,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(minPasswordLength, maxPasswordLength),
+						},
+						keyMinUpper: &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultMinUpper,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						keyMinLower: &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultMinLower,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						keyMinDigits: &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultMinDigits,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						keyMinSymbols: &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultMinSymbols,
+							ForceNew:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						keySymbols: &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  defaultSymbols,
+							ForceNew: true,
+						},
+						keyExcludeChars: &schema.Schema{
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
+							Default:  "",
 							ForceNew: true,
 						},
-						keyLength: &schema.Schema{
-							Type:     schema.TypeInt,
+						keyResetExisting: &schema.Schema{
+							Type:     schema.TypeBool,
 							Optional: true,
-							Default:  defaultPasswordLength,
+							Default:  false,
 							ForceNew: true,
 						},
 					},
 				},
 			},
+			keyPasswordHash: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyAdoptExisting: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			keyIPs: &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
@@ -107,10 +215,31 @@ func resourceSubuser() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
-			keyDomain: &schema.Schema{
-				Type:     schema.TypeString,
+			keyWebsiteAccessDisabled: &schema.Schema{
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  defaultDomainID,
+				Default:  false,
+			},
+			keyDomain: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultDomainID,
+				ValidateFunc: validateDomainID,
+			},
+			keyLinkBranding: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultLinkBrandingID,
+				ValidateFunc: validateLinkBrandingID,
+			},
+			keyDomains: &schema.Schema{
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{keyDomain},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateDomainID,
+				},
 			},
 		},
 	}
@@ -128,81 +257,144 @@ func resourceSubuserCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("password block may appear only once")
 	}
 
-	passLength := defaultPasswordLength
+	policy := defaultPasswordPolicy()
 	passDest := ""
+	passReset := false
 	if len(passConfigList) == 1 {
 		passConfig := passConfigList[0].(map[string]interface{})
 		passDest = passConfig[keyDestination].(string)
-		passLength = passConfig[keyLength].(int)
+		passReset = passConfig[keyResetExisting].(bool)
+		policy = passwordPolicyFromConfig(passConfig)
 	}
 
-	passwordBytes, err := genPassword(passLength)
+	// Validate the password before any API call is made.
+	passwordBytes, supplied, err := suppliedPassword(d)
 	if err != nil {
 		return err
 	}
 
-	err = writeFile(passDest, passwordBytes)
-	if err != nil {
-		return errors.Wrap(err, "unable to save generated password")
+	if !supplied {
+		err = policy.validate()
+		if err != nil {
+			return errors.Wrap(err, "invalid password policy")
+		}
 	}
 
-	password := string(passwordBytes)
+	apiKey := m.(*Config).APIKey
 
-	payload := map[string]interface{}{
-		"username": username,
-		"email":    email,
-		"password": password,
-		"ips":      ips,
+	var existing *subuser
+	if d.Get(keyAdoptExisting).(bool) {
+		existing, err = getSubuser(apiKey, username)
+		if err != nil {
+			return errors.Wrap(err, "failed to look up existing subuser")
+		}
 	}
 
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return err
+	// An adopted subuser keeps its password unless it is supplied or reset,
+	// so only a new subuser or a reset needs a generated password.
+	adopted := existing != nil
+	generated := !supplied && (!adopted || passReset)
+	if generated {
+		if passDest == "" {
+			return fmt.Errorf("password.%s is required unless password.%s or password.%s is set", keyDestination, keyValue, keySourceFile)
+		}
+
+		passwordBytes, err = genPassword(policy)
+		if err != nil {
+			return err
+		}
 	}
 
-	apiKey := m.(*Config).APIKey
-	request := sendgrid.GetRequest(apiKey, "/v3/subusers", sendgridAddress)
-	request.Method = http.MethodPost
-	request.Body = data
+	password := string(passwordBytes)
+	if adopted {
+		if existing.Email != email {
+			return fmt.Errorf("existing subuser %s has email %s, expected %s", username, existing.Email, email)
+		}
 
-	_, err = doRequest(request, withStatus(http.StatusCreated), withRateLimit(createSubuserRate))
-	if err != nil {
-		return errors.Wrap(err, "failed to create subuser")
-	}
+		// Track the subuser from here on so that a failure below taints it
+		// instead of leaving it out of state.
+		d.SetId(username)
 
-	createStateConf := &resource.StateChangeConf{
-		Pending:                   []string{statusWaiting},
-		Target:                    []string{statusDone},
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
-		Delay:                     defaultBackoff,
-		MinTimeout:                defaultBackoff,
-		ContinuousTargetOccurence: 3,
-		Refresh: func() (interface{}, string, error) {
-			user, err := getSubuser(apiKey, username)
-			if l, ok := err.(ratelimitError); ok {
-				time.Sleep(l.timeout)
-				return nil, statusWaiting, nil
-			} else if err != nil {
-				return nil, "", err
-			} else if user == nil {
-				return nil, statusWaiting, nil
+		if supplied {
+			err = setPassword(apiKey, username, password)
+			if err != nil {
+				return errors.Wrap(err, "failed to set password of existing subuser")
+			}
+		} else if passReset {
+			err = setPassword(apiKey, username, password)
+			if err != nil {
+				return errors.Wrap(err, "failed to reset password of existing subuser")
 			}
 
-			return user, statusDone, nil
-		},
-	}
+			err = writeFile(passDest, passwordBytes)
+			if err != nil {
+				return errors.Wrap(err, "unable to save generated password")
+			}
+		} else {
+			log.Printf("[WARN] adopted existing subuser %s without changing its password", username)
+		}
 
-	_, err = createStateConf.WaitForState()
-	if err != nil {
-		return fmt.Errorf("error waiting for subuser (%s) to be created: %s", d.Id(), err)
+		err = setIPs(apiKey, username, ips)
+		if err != nil {
+			return errors.Wrap(err, "failed to set IPs of existing subuser")
+		}
+	} else {
+		err = createSubuser(apiKey, username, email, password, ips)
+		if err != nil {
+			return errors.Wrap(err, "failed to create subuser")
+		}
+
+		d.SetId(username)
+
+		if !supplied {
+			err = writeFile(passDest, passwordBytes)
+			if err != nil {
+				return errors.Wrap(err, "unable to save generated password")
+			}
+		}
+
+		createStateConf := &resource.StateChangeConf{
+			Pending:                   []string{statusWaiting},
+			Target:                    []string{statusDone},
+			Timeout:                   d.Timeout(schema.TimeoutCreate),
+			Delay:                     defaultBackoff,
+			MinTimeout:                defaultBackoff,
+			ContinuousTargetOccurence: 3,
+			Refresh: func() (interface{}, string, error) {
+				user, err := getSubuser(apiKey, username)
+				if l, ok := err.(ratelimitError); ok {
+					time.Sleep(l.timeout)
+					return nil, statusWaiting, nil
+				} else if err != nil {
+					return nil, "", err
+				} else if user == nil {
+					return nil, statusWaiting, nil
+				}
+
+				return user, statusDone, nil
+			},
+		}
+
+		_, err = createStateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("error waiting for subuser (%s) to be created: %s", d.Id(), err)
+		}
 	}
 
 	d.SetPartial(keyUsername)
 	d.SetPartial(keyEmail)
+	// The password of an adopted subuser is unknown until one is set
+	if supplied || generated {
+		d.Set(keyPasswordHash, hashPassword(password))
+	}
+	clearPasswordValue(d)
 	d.SetPartial(keyPassword)
+	d.SetPartial(keyPasswordHash)
+	d.SetPartial(keyAdoptExisting)
 
+	// An adopted subuser may already be disabled, so its flags are always set.
 	isDisabled := d.Get(keyDisabled).(bool)
-	if isDisabled {
+	if isDisabled || adopted {
 		err = setDisabled(apiKey, username, isDisabled)
 		if err != nil {
 			return errors.Wrap(err, "failed to disable subuser")
@@ -211,6 +403,16 @@ func resourceSubuserCreate(d *schema.ResourceData, m interface{}) error {
 		d.SetPartial(keyDisabled)
 	}
 
+	isWebsiteAccessDisabled := d.Get(keyWebsiteAccessDisabled).(bool)
+	if isWebsiteAccessDisabled || adopted {
+		err = setWebsiteAccessDisabled(apiKey, username, isWebsiteAccessDisabled)
+		if err != nil {
+			return errors.Wrap(err, "failed to disable website access for subuser")
+		}
+
+		d.SetPartial(keyWebsiteAccessDisabled)
+	}
+
 	domain := d.Get(keyDomain).(string)
 	if domain != defaultDomainID {
 		err = setDomain(apiKey, username, domain)
@@ -221,9 +423,27 @@ func resourceSubuserCreate(d *schema.ResourceData, m interface{}) error {
 		d.SetPartial(keyDomain)
 	}
 
-	d.Partial(false)
+	linkBranding := d.Get(keyLinkBranding).(string)
+	if linkBranding != defaultLinkBrandingID {
+		err = setLinkBranding(apiKey, username, linkBranding)
+		if err != nil {
+			return errors.Wrap(err, "failed to set link branding")
+		}
+
+		d.SetPartial(keyLinkBranding)
+	}
 
-	d.SetId(username)
+	domains := d.Get(keyDomains).(*schema.Set)
+	if domains.Len() > 0 {
+		err = setDomains(apiKey, username, schema.NewSet(schema.HashString, nil), domains)
+		if err != nil {
+			return errors.Wrap(err, "failed to set authenticated domains")
+		}
+
+		d.SetPartial(keyDomains)
+	}
+
+	d.Partial(false)
 
 	return resourceSubuserRead(d, m)
 }
@@ -238,9 +458,29 @@ func resourceSubuserRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
-	domainID, err := getDomain(apiKey, user.Username)
+	// Every associated domain is read so that subusers with several domains
+	// can be refreshed and imported; domain is only used for a single one.
+	domains, err := getDomains(apiKey, user.Username)
 	if err != nil {
-		return errors.Wrap(err, "unable to get domain authentication for subuser")
+		return errors.Wrap(err, "unable to get domain authentications for subuser")
+	}
+
+	if _, ok := d.GetOk(keyDomains); ok || len(domains) > 1 {
+		d.Set(keyDomain, defaultDomainID)
+		d.Set(keyDomains, domains)
+	} else {
+		domainID := defaultDomainID
+		if len(domains) == 1 {
+			domainID = domains[0].(string)
+		}
+
+		d.Set(keyDomain, domainID)
+		d.Set(keyDomains, nil)
+	}
+
+	linkBrandingID, err := getSubuserLinkBranding(apiKey, user.Username)
+	if err != nil {
+		return errors.Wrap(err, "unable to get link branding for subuser")
 	}
 
 	ips, err := getIPs(apiKey, user.Username)
@@ -248,10 +488,20 @@ func resourceSubuserRead(d *schema.ResourceData, m interface{}) error {
 		return errors.Wrap(err, "unable to get IPs for subuser")
 	}
 
+	// Sendgrid only documents changing website access, so when it doesn't
+	// report the state the value last applied is kept.
+	websiteAccessDisabled, err := getWebsiteAccessDisabled(apiKey, user.Username)
+	if err == errWebsiteAccessUnsupported {
+		websiteAccessDisabled = d.Get(keyWebsiteAccessDisabled).(bool)
+	} else if err != nil {
+		return errors.Wrap(err, "unable to get website access for subuser")
+	}
+
 	d.Set(keyUsername, user.Username)
 	d.Set(keyEmail, user.Email)
 	d.Set(keyDisabled, user.Disabled)
-	d.Set(keyDomain, domainID)
+	d.Set(keyWebsiteAccessDisabled, websiteAccessDisabled)
+	d.Set(keyLinkBranding, linkBrandingID)
 	d.Set(keyIPs, ips)
 
 	return nil
@@ -263,6 +513,26 @@ func resourceSubuserUpdate(d *schema.ResourceData, m interface{}) error {
 	apiKey := m.(*Config).APIKey
 	username := d.Get(keyUsername).(string)
 
+	if d.HasChange(keyPasswordValue) || d.HasChange(keyPasswordSourceFile) || d.HasChange(keyPasswordHash) {
+		// Removing a supplied password is refused by CustomizeDiff
+		password, supplied, err := suppliedPassword(d)
+		if err != nil {
+			return err
+		} else if !supplied {
+			return fmt.Errorf("no password.%s or password.%s to set", keyValue, keySourceFile)
+		}
+
+		err = setPassword(apiKey, username, string(password))
+		if err != nil {
+			return errors.Wrap(err, "failed to set user.password")
+		}
+
+		d.Set(keyPasswordHash, hashPassword(string(password)))
+		clearPasswordValue(d)
+		d.SetPartial(keyPassword)
+		d.SetPartial(keyPasswordHash)
+	}
+
 	if d.HasChange(keyDisabled) {
 		disabled := d.Get(keyDisabled).(bool)
 		err := setDisabled(apiKey, username, disabled)
@@ -273,18 +543,19 @@ func resourceSubuserUpdate(d *schema.ResourceData, m interface{}) error {
 		d.SetPartial(keyDisabled)
 	}
 
-	if d.HasChange(keyIPs) {
-		ips := d.Get(keyIPs).(*schema.Set).List()
-		data, err := json.Marshal(ips)
+	if d.HasChange(keyWebsiteAccessDisabled) {
+		websiteAccessDisabled := d.Get(keyWebsiteAccessDisabled).(bool)
+		err := setWebsiteAccessDisabled(apiKey, username, websiteAccessDisabled)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "failed to set user.website_access_disabled")
 		}
 
-		request := sendgrid.GetRequest(apiKey, fmt.Sprintf("/v3/subusers/%s/ips", username), sendgridAddress)
-		request.Method = http.MethodPut
-		request.Body = data
+		d.SetPartial(keyWebsiteAccessDisabled)
+	}
 
-		_, err = doRequest(request, withStatus(http.StatusOK))
+	if d.HasChange(keyIPs) {
+		ips := d.Get(keyIPs).(*schema.Set).List()
+		err := setIPs(apiKey, username, ips)
 		if err != nil {
 			return err
 		}
@@ -292,14 +563,38 @@ func resourceSubuserUpdate(d *schema.ResourceData, m interface{}) error {
 		d.SetPartial(keyIPs)
 	}
 
-	if d.HasChange(keyDomain) {
-		domainID := d.Get(keyDomain).(string)
-		err := setDomain(apiKey, username, domainID)
+	// domain and domains describe the same associations, so compute the
+	// desired set from both before changing anything.
+	domainsChanged := d.HasChange(keyDomain) || d.HasChange(keyDomains)
+	if domainsChanged {
+		oldDomain, newDomain := d.GetChange(keyDomain)
+		oldDomains, newDomains := d.GetChange(keyDomains)
+
+		var err error
+		if oldDomains.(*schema.Set).Len() == 0 && newDomains.(*schema.Set).Len() == 0 {
+			err = setDomain(apiKey, username, newDomain.(string))
+		} else {
+			err = setDomains(apiKey, username,
+				subuserDomainSet(oldDomain.(string), oldDomains.(*schema.Set)),
+				subuserDomainSet(newDomain.(string), newDomains.(*schema.Set)))
+		}
+
 		if err != nil {
-			return errors.Wrap(err, "failed to set user.domain")
+			return errors.Wrap(err, "failed to set user.domains")
 		}
 
 		d.SetPartial(keyDomain)
+		d.SetPartial(keyDomains)
+	}
+
+	if d.HasChange(keyLinkBranding) {
+		linkBrandingID := d.Get(keyLinkBranding).(string)
+		err := setLinkBranding(apiKey, username, linkBrandingID)
+		if err != nil {
+			return errors.Wrap(err, "failed to set user.link_branding")
+		}
+
+		d.SetPartial(keyLinkBranding)
 	}
 
 	d.Partial(false)
@@ -310,8 +605,18 @@ func resourceSubuserUpdate(d *schema.ResourceData, m interface{}) error {
 		eg.Go(func() error { return waitForSubuser(d, m) })
 	}
 
-	if d.HasChange(keyDomain) {
+	if d.HasChange(keyWebsiteAccessDisabled) {
+		eg.Go(func() error { return waitForWebsiteAccess(d, m) })
+	}
+
+	if domainsChanged && d.Get(keyDomains).(*schema.Set).Len() == 0 {
 		eg.Go(func() error { return waitForDomain(d, m) })
+	} else if domainsChanged {
+		eg.Go(func() error { return waitForDomains(d, m) })
+	}
+
+	if d.HasChange(keyLinkBranding) {
+		eg.Go(func() error { return waitForLinkBranding(d, m) })
 	}
 
 	if d.HasChange(keyIPs) {
@@ -338,6 +643,67 @@ func resourceSubuserDelete(d *schema.ResourceData, m interface{}) error {
 	return errors.Wrap(err, "failed to delete subuser")
 }
 
+func createSubuser(apiKey, username, email, password string, ips []interface{}) error {
+	payload := map[string]interface{}{
+		"username": username,
+		"email":    email,
+		"password": password,
+		"ips":      ips,
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, "/v3/subusers", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusCreated), withRateLimit(createSubuserRate))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func setPassword(apiKey, username, password string) error {
+	data, err := json.Marshal(map[string]string{"password": password})
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, "/v3/subusers/"+username+"/password", sendgridAddress)
+	request.Method = http.MethodPut
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNoContent))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func setIPs(apiKey, username string, ips []interface{}) error {
+	data, err := json.Marshal(ips)
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, fmt.Sprintf("/v3/subusers/%s/ips", username), sendgridAddress)
+	request.Method = http.MethodPut
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func setDisabled(apiKey, username string, disabled bool) error {
 	var buf bytes.Buffer
 	fmt.Fprintf(&buf, `{"disabled":%t}`, disabled)
@@ -354,6 +720,53 @@ func setDisabled(apiKey, username string, disabled bool) error {
 	return nil
 }
 
+func setWebsiteAccessDisabled(apiKey, username string, disabled bool) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"disabled":%t}`, disabled)
+
+	request := sendgrid.GetRequest(apiKey, "/v3/subusers/"+username+"/website_access", sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = buf.Bytes()
+
+	_, err := doRequest(request, withStatus(http.StatusNoContent))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// errWebsiteAccessUnsupported is returned when Sendgrid does not report the
+// website access state of a subuser.
+var errWebsiteAccessUnsupported = errors.New("website access is not reported for this subuser")
+
+func getWebsiteAccessDisabled(apiKey, username string) (bool, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/subusers/"+username+"/website_access", sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound), withStatus(http.StatusMethodNotAllowed))
+	if err != nil {
+		return false, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return false, errWebsiteAccessUnsupported
+	}
+
+	data := struct {
+		Disabled *bool `json:"disabled"`
+	}{}
+
+	err = json.Unmarshal([]byte(res.Body), &data)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal website access query response")
+	} else if data.Disabled == nil {
+		return false, errWebsiteAccessUnsupported
+	}
+
+	return *data.Disabled, nil
+}
+
 func setDomain(apiKey, username string, domain string) error {
 	var uri string
 	var method rest.Method
@@ -368,9 +781,12 @@ func setDomain(apiKey, username string, domain string) error {
 		uri = "/v3/whitelabel/domains/" + domain + "/subuser"
 		method = rest.Post
 
-		var buf bytes.Buffer
-		fmt.Fprintf(&buf, `{"username":%s}`, username)
-		body = buf.Bytes()
+		data, err := json.Marshal(map[string]string{"username": username})
+		if err != nil {
+			return err
+		}
+
+		body = data
 	}
 
 	request := sendgrid.GetRequest(apiKey, uri, sendgridAddress)
@@ -386,138 +802,269 @@ func setDomain(apiKey, username string, domain string) error {
 	return nil
 }
 
-func getDomain(apiKey, username string) (string, error) {
-	request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/domains/subuser", sendgridAddress)
+// subuserDomainSet returns the domains a subuser should be associated with,
+// given either a single domain or a set of them.
+func subuserDomainSet(domain string, domains *schema.Set) *schema.Set {
+	// Always hash with HashString so that sets built here can be compared.
+	if domains.Len() > 0 {
+		return schema.NewSet(schema.HashString, domains.List())
+	}
+
+	set := schema.NewSet(schema.HashString, nil)
+	if domain != defaultDomainID {
+		set.Add(domain)
+	}
+
+	return set
+}
+
+// setDomains removes the domains no longer wanted before adding new ones, so
+// that a domain in both sets is never disassociated.
+func setDomains(apiKey, username string, oldDomains, newDomains *schema.Set) error {
+	for _, domain := range oldDomains.Difference(newDomains).List() {
+		request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/domains/subuser", sendgridAddress)
+		request.Method = http.MethodDelete
+		request.QueryParams = map[string]string{"username": username, "domain_id": domain.(string)}
+
+		res, err := doRequest(request, withStatus(http.StatusNoContent), withStatus(http.StatusNotFound))
+		if err != nil {
+			return errors.Wrapf(err, "failed to disassociate domain %s", domain)
+		} else if res.StatusCode == http.StatusNotFound {
+			log.Printf("[DEBUG] domain %s was already disassociated from subuser %s", domain, username)
+		}
+	}
+
+	for _, domain := range newDomains.Difference(oldDomains).List() {
+		data, err := json.Marshal(map[string]string{"username": username})
+		if err != nil {
+			return err
+		}
+
+		request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/domains/"+domain.(string)+"/subuser:add", sendgridAddress)
+		request.Method = http.MethodPost
+		request.Body = data
+
+		_, err = doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusCreated))
+		if err != nil {
+			return errors.Wrapf(err, "failed to associate domain %s", domain)
+		}
+	}
+
+	return nil
+}
+
+func getDomains(apiKey, username string) ([]interface{}, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/domains/subuser/all", sendgridAddress)
 	request.QueryParams = map[string]string{"username": username}
 	request.Method = http.MethodGet
 
-	res, err := doRequest(request, withStatus(http.StatusOK))
-	if err != nil {
-		return "", errors.Wrap(err, "failed to query domain")
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return []interface{}{}, nil
 	}
 
-	data := struct {
-		ID int64 `json:"id"`
-	}{}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query domains")
+	}
 
-	err = json.Unmarshal([]byte(res.Body), &data)
+	ids, err := parseWhitelabelIDs([]byte(res.Body))
 	if err != nil {
-		return "", errors.Wrap(err, "failed to unmarshal domain query response")
+		return nil, errors.Wrap(err, "failed to unmarshal domains query response")
 	}
 
-	return strconv.FormatInt(data.ID, 10), nil
+	return ids, nil
 }
 
-func getIPs(apiKey, username string) ([]interface{}, error) {
-	request := sendgrid.GetRequest(apiKey, "/v3/ips", sendgridAddress)
-	request.Method = http.MethodGet
+// parseWhitelabelIDs accepts either a single whitelabel object or a list of
+// them, as returned by the domain and link branding subuser endpoints.
+func parseWhitelabelIDs(body []byte) ([]interface{}, error) {
+	type whitelabel struct {
+		ID *int64 `json:"id"`
+	}
+
+	var whitelabels []whitelabel
+
+	body = bytes.TrimSpace(body)
+	switch {
+	case len(body) == 0 || string(body) == "null":
+		return []interface{}{}, nil
+	case body[0] == '[':
+		if err := json.Unmarshal(body, &whitelabels); err != nil {
+			return nil, err
+		}
+	case body[0] == '{':
+		var single whitelabel
+		if err := json.Unmarshal(body, &single); err != nil {
+			return nil, err
+		}
+
+		whitelabels = append(whitelabels, single)
+	default:
+		return nil, fmt.Errorf("unexpected whitelabel response: %s", body)
+	}
 
-	// TODO: pagination
-	request.QueryParams = map[string]string{
-		"subuser": username,
-		"limit":   "500",
+	ids := make([]interface{}, 0, len(whitelabels))
+	for _, w := range whitelabels {
+		if w.ID == nil {
+			return nil, fmt.Errorf("whitelabel response is missing an id: %s", body)
+		}
+
+		ids = append(ids, strconv.FormatInt(*w.ID, 10))
 	}
 
-	res, err := doRequest(request, withStatus(http.StatusOK))
+	return ids, nil
+}
+
+func validateDomainID(v interface{}, k string) ([]string, []error) {
+	_, err := strconv.ParseInt(v.(string), 10, 64)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to query IPs")
+		return nil, []error{fmt.Errorf("%s must be a numeric domain ID, got: %s", k, v)}
 	}
 
-	data := []struct {
-		IP string `json:"ip"`
-	}{}
+	return nil, nil
+}
 
-	err = json.Unmarshal([]byte(res.Body), &data)
+func validateLinkBrandingID(v interface{}, k string) ([]string, []error) {
+	_, err := strconv.ParseInt(v.(string), 10, 64)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal IP query response")
+		return nil, []error{fmt.Errorf("%s must be a numeric link branding ID, got: %s", k, v)}
 	}
 
-	ips := make([]interface{}, 0, len(data))
-	for _, ip := range data {
-		ips = append(ips, ip.IP)
+	return nil, nil
+}
+
+func setLinkBranding(apiKey, username string, linkBranding string) error {
+	var uri string
+	var method rest.Method
+	var body []byte
+	var queryParams map[string]string
+
+	if linkBranding == defaultLinkBrandingID {
+		uri = "/v3/whitelabel/links/subuser"
+		method = rest.Delete
+		queryParams = map[string]string{"username": username}
+	} else {
+		uri = "/v3/whitelabel/links/" + linkBranding + "/subuser"
+		method = rest.Post
+
+		data, err := json.Marshal(map[string]string{"username": username})
+		if err != nil {
+			return err
+		}
+
+		body = data
 	}
 
-	return ips, nil
+	request := sendgrid.GetRequest(apiKey, uri, sendgridAddress)
+	request.Method = method
+	request.Body = body
+	request.QueryParams = queryParams
+
+	_, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusCreated), withStatus(http.StatusNoContent))
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func getSubuser(apiKey, name string) (*subuser, error) {
-	request := sendgrid.GetRequest(apiKey, "/v3/subusers/"+name, sendgridAddress)
+func getSubuserLinkBranding(apiKey, username string) (string, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/whitelabel/links/subuser", sendgridAddress)
+	request.QueryParams = map[string]string{"username": username}
 	request.Method = http.MethodGet
 
 	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
 	if res != nil && res.StatusCode == http.StatusNotFound {
-		return nil, nil
+		return defaultLinkBrandingID, nil
 	}
 
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to query subuser")
+		return "", errors.Wrap(err, "failed to query link branding")
 	}
 
-	var user subuser
-
-	err = json.Unmarshal([]byte(res.Body), &user)
+	ids, err := parseWhitelabelIDs([]byte(res.Body))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal subuser query response")
+		return "", errors.Wrap(err, "failed to unmarshal link branding query response")
 	}
 
-	return &user, nil
+	if len(ids) == 0 {
+		return defaultLinkBrandingID, nil
+	}
+
+	return ids[0].(string), nil
 }
 
-func randomBytes(n int) ([]byte, error) {
-	buf := make([]byte, n)
-	_, err := rand.Read(buf)
+func getIPs(apiKey, username string) ([]interface{}, error) {
+	data, err := listIPs(apiKey, map[string]string{"subuser": username})
 	if err != nil {
 		return nil, err
 	}
 
-	return buf, nil
+	ips := make([]interface{}, 0, len(data))
+	for _, ip := range data {
+		ips = append(ips, ip.IP)
+	}
+
+	return ips, nil
 }
 
-func genPassword(length int) ([]byte, error) {
-	const numbers = "0123456789"
-	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
-	const validChars = numbers + letters + "!@#$%^&*()-_=+"
+func getSubuser(apiKey, name string) (*subuser, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/subusers/"+name, sendgridAddress)
+	request.Method = http.MethodGet
 
-	bytes, err := randomBytes(length + 2)
-	if err != nil {
-		return nil, err
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
 	}
 
-	for i := 0; i < length; i++ {
-		bytes[i] = validChars[bytes[i]%byte(len(validChars))]
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query subuser")
 	}
 
-	// Sendgrid requires at least one letter and one number
-	i := int(bytes[length]) % length
-	i2 := int(bytes[length+1]) % length
-	if i == i2 {
-		i2 = (i2 + 1) % length
-	}
+	var user subuser
 
-	bytes[i] = numbers[bytes[length]%byte(len(numbers))]
-	bytes[i2] = letters[bytes[length+1]%byte(len(letters))]
+	err = json.Unmarshal([]byte(res.Body), &user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal subuser query response")
+	}
 
-	return bytes[:length], nil
+	return &user, nil
 }
 
+// parseSubuserImportID accepts either id:password_destination:password_length
+// for a generated password, or only id for a supplied or unchanged one.
 func parseSubuserImportID(id string) (string, []map[string]interface{}, error) {
 	parts := strings.SplitN(id, ":", 3)
 
-	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
-		return "", nil, fmt.Errorf("unexpected format of ID (%s), expected id:password_destination:password_length", id)
+	if parts[0] == "" || (len(parts) != 1 && (len(parts) != 3 || parts[1] == "" || parts[2] == "")) {
+		return "", nil, fmt.Errorf("unexpected format of ID (%s), expected id or id:password_destination:password_length", id)
 	}
 
 	realID := parts[0]
-	passDest := parts[1]
-	passLen, err := strconv.ParseInt(parts[2], 10, 32)
-	if err != nil {
-		return "", nil, fmt.Errorf("invalid password length: %s", parts[2])
+	passDest := ""
+	passLen := int64(defaultPasswordLength)
+	if len(parts) == 3 {
+		var err error
+		passDest = parts[1]
+		passLen, err = strconv.ParseInt(parts[2], 10, 32)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid password length: %s", parts[2])
+		}
 	}
 
 	return realID, []map[string]interface{}{
 		map[string]interface{}{
-			keyDestination: passDest,
-			keyLength:      passLen,
+			keyDestination:   passDest,
+			keyLength:        passLen,
+			keyResetExisting: false,
+			keyMinUpper:      defaultMinUpper,
+			keyMinLower:      defaultMinLower,
+			keyMinDigits:     defaultMinDigits,
+			keyMinSymbols:    defaultMinSymbols,
+			keySymbols:       defaultSymbols,
+			keyExcludeChars:  "",
+			keyValue:         "",
+			keySourceFile:    "",
 		},
 	}, nil
 }
@@ -564,11 +1111,54 @@ func waitForSubuser(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func waitForWebsiteAccess(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	username := d.Get(keyUsername).(string)
+	disabled := d.Get(keyWebsiteAccessDisabled).(bool)
+
+	createStateConf := &resource.StateChangeConf{
+		Pending:                   []string{statusWaiting},
+		Target:                    []string{statusDone},
+		Timeout:                   d.Timeout(schema.TimeoutUpdate),
+		Delay:                     defaultBackoff,
+		MinTimeout:                defaultBackoff,
+		ContinuousTargetOccurence: 3,
+		Refresh: func() (interface{}, string, error) {
+			gotDisabled, err := getWebsiteAccessDisabled(config.APIKey, username)
+			if l, ok := err.(ratelimitError); ok {
+				time.Sleep(l.timeout)
+				return nil, statusWaiting, nil
+			} else if err == errWebsiteAccessUnsupported {
+				// Nothing to wait for; the successful update is trusted
+				return disabled, statusDone, nil
+			} else if err != nil {
+				return nil, "", err
+			} else if gotDisabled != disabled {
+				return nil, statusWaiting, nil
+			}
+
+			return disabled, statusDone, nil
+		},
+	}
+
+	_, err := createStateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for website access for subuser (%s) to become consistent: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
 func waitForDomain(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	username := d.Get(keyUsername).(string)
 	domain := d.Get(keyDomain).(string)
 
+	wantDomains := []interface{}{}
+	if domain != defaultDomainID {
+		wantDomains = append(wantDomains, domain)
+	}
+
 	createStateConf := &resource.StateChangeConf{
 		Pending:                   []string{statusWaiting},
 		Target:                    []string{statusDone},
@@ -577,13 +1167,13 @@ func waitForDomain(d *schema.ResourceData, m interface{}) error {
 		MinTimeout:                defaultBackoff,
 		ContinuousTargetOccurence: 3,
 		Refresh: func() (interface{}, string, error) {
-			gotDomain, err := getDomain(config.APIKey, username)
+			gotDomains, err := getDomains(config.APIKey, username)
 			if l, ok := err.(ratelimitError); ok {
 				time.Sleep(l.timeout)
 				return "", statusWaiting, nil
 			} else if err != nil {
 				return "", "", err
-			} else if gotDomain != domain {
+			} else if !sliceContentsAreEqual(gotDomains, wantDomains) {
 				return "", statusWaiting, nil
 			}
 
@@ -599,6 +1189,76 @@ func waitForDomain(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func waitForLinkBranding(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	username := d.Get(keyUsername).(string)
+	linkBranding := d.Get(keyLinkBranding).(string)
+
+	createStateConf := &resource.StateChangeConf{
+		Pending:                   []string{statusWaiting},
+		Target:                    []string{statusDone},
+		Timeout:                   d.Timeout(schema.TimeoutUpdate),
+		Delay:                     defaultBackoff,
+		MinTimeout:                defaultBackoff,
+		ContinuousTargetOccurence: 3,
+		Refresh: func() (interface{}, string, error) {
+			gotLinkBranding, err := getSubuserLinkBranding(config.APIKey, username)
+			if l, ok := err.(ratelimitError); ok {
+				time.Sleep(l.timeout)
+				return "", statusWaiting, nil
+			} else if err != nil {
+				return "", "", err
+			} else if gotLinkBranding != linkBranding {
+				return "", statusWaiting, nil
+			}
+
+			return linkBranding, statusDone, nil
+		},
+	}
+
+	_, err := createStateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for link branding for subuser (%s) to become consistent: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitForDomains(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	username := d.Get(keyUsername).(string)
+	domains := d.Get(keyDomains).(*schema.Set).List()
+
+	createStateConf := &resource.StateChangeConf{
+		Pending:                   []string{statusWaiting},
+		Target:                    []string{statusDone},
+		Timeout:                   d.Timeout(schema.TimeoutUpdate),
+		Delay:                     defaultBackoff,
+		MinTimeout:                defaultBackoff,
+		ContinuousTargetOccurence: 3,
+		Refresh: func() (interface{}, string, error) {
+			gotDomains, err := getDomains(config.APIKey, username)
+			if l, ok := err.(ratelimitError); ok {
+				time.Sleep(l.timeout)
+				return nil, statusWaiting, nil
+			} else if err != nil {
+				return nil, "", err
+			} else if !sliceContentsAreEqual(gotDomains, domains) {
+				return nil, statusWaiting, nil
+			}
+
+			return domains, statusDone, nil
+		},
+	}
+
+	_, err := createStateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for domains for subuser (%s) to become consistent: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
 func waitForIPs(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	username := d.Get(keyUsername).(string)
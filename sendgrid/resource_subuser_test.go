@@ -3,10 +3,12 @@ package sendgrid
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
@@ -28,9 +30,13 @@ func TestAccResourceSubuser(t *testing.T) {
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.#", "1"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.destination", passDest),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.length", "16"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.min_digits", "1"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "disabled", "false"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "website_access_disabled", "false"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "ips.#", "1"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "domain", "0"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "domains.#", "0"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "link_branding", "0"),
 				),
 				PreventDiskCleanup: true,
 			},
@@ -43,9 +49,22 @@ func TestAccResourceSubuser(t *testing.T) {
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.#", "1"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.destination", passDest),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.length", "16"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.min_digits", "1"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "disabled", "true"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "website_access_disabled", "false"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "ips.#", "1"),
 					resource.TestCheckResourceAttr("sendgrid_subuser.test", "domain", "0"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "domains.#", "0"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "link_branding", "0"),
+				),
+				PreventDiskCleanup: true,
+			},
+			{
+				Config: testResourceSubuserWebsiteAccessConfig(username, passDest, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSubuserCheckSendgrid("sendgrid_subuser.test"),
+					testResourceSubuserCheckWebsiteAccess("sendgrid_subuser.test"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "website_access_disabled", "true"),
 				),
 				PreventDiskCleanup: true,
 			},
@@ -54,11 +73,192 @@ func TestAccResourceSubuser(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateId:     fmt.Sprintf("%s:%s:16", username, passDest),
+				// The hash of a generated password can't be read back
+				ImportStateVerifyIgnore: []string{"password_hash"},
+			},
+		},
+	})
+}
+
+func TestAccResourceSubuser_adoptExisting(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-sg-test-subuser")
+	passDest := createTempFile()
+	defer os.Remove(passDest)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testCreateExistingSubuser(t, username),
+				Config: fmt.Sprintf(`
+resource "sendgrid_subuser" "test" {
+	username = "%[1]s"
+	email    = "%[1]s@example.org"
+	password {
+		destination    = "%[2]s"
+		reset_existing = true
+	}
+
+	adopt_existing = true
+
+	ips = %[3]s
+}`, username, passDest, testIPsRaw),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSubuserCheckSendgrid("sendgrid_subuser.test"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "username", username),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.reset_existing", "true"),
+				),
+				PreventDiskCleanup: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSubuser_adoptExistingKeepPassword(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-sg-test-subuser")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testCreateExistingSubuser(t, username),
+				Config: fmt.Sprintf(`
+resource "sendgrid_subuser" "test" {
+	username = "%[1]s"
+	email    = "%[1]s@example.org"
+	password {}
+
+	adopt_existing = true
+
+	ips = %[2]s
+}`, username, testIPsRaw),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSubuserCheckSendgrid("sendgrid_subuser.test"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password_hash", ""),
+				),
+			},
+		},
+	})
+}
+
+// testCreateExistingSubuser creates a subuser outside of Terraform for tests
+// which adopt it.
+func testCreateExistingSubuser(t *testing.T, username string) func() {
+	return func() {
+		ips := make([]interface{}, 0, len(testIPs))
+		for _, ip := range testIPs {
+			ips = append(ips, ip)
+		}
+
+		password, err := genPassword(defaultPasswordPolicy())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiKey := os.Getenv("SENDGRID_API_KEY")
+		err = createSubuser(apiKey, username, username+"@example.org", string(password), ips)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAccResourceSubuser_suppliedPassword(t *testing.T) {
+	username := acctest.RandomWithPrefix("tf-sg-test-subuser")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceSubuserSuppliedPasswordConfig(username, "Sup3r-secret-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSubuserCheckSendgrid("sendgrid_subuser.test"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.value", ""),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password_hash", hashPassword("Sup3r-secret-1")),
+				),
+			},
+			{
+				Config: testResourceSubuserSuppliedPasswordConfig(username, "Sup3r-secret-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceSubuserCheckSendgrid("sendgrid_subuser.test"),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password.0.value", ""),
+					resource.TestCheckResourceAttr("sendgrid_subuser.test", "password_hash", hashPassword("Sup3r-secret-2")),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "sendgrid_subuser" "test" {
+	username = "%[1]s"
+	email    = "%[1]s@example.org"
+	password {}
+
+	ips = %[2]s
+}`, username, testIPsRaw),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("may not be removed"),
+			},
+			{
+				ResourceName:            "sendgrid_subuser.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           username,
+				ImportStateVerifyIgnore: []string{"password_hash"},
 			},
 		},
 	})
 }
 
+func TestParseSubuserImportID(t *testing.T) {
+	id, password, err := parseSubuserImportID("user1")
+	if err != nil || id != "user1" || password[0][keyDestination] != "" {
+		t.Errorf("parseSubuserImportID(user1) = %v, %v, %v", id, password, err)
+	}
+
+	id, password, err = parseSubuserImportID("user1:./user1.pass:20")
+	if err != nil || id != "user1" || password[0][keyDestination] != "./user1.pass" || password[0][keyLength] != int64(20) {
+		t.Errorf("parseSubuserImportID(user1:./user1.pass:20) = %v, %v, %v", id, password, err)
+	}
+
+	for _, invalid := range []string{"", "user1:./user1.pass", "user1::20", "user1:./user1.pass:x"} {
+		if _, _, err := parseSubuserImportID(invalid); err == nil {
+			t.Errorf("parseSubuserImportID(%q) returned no error", invalid)
+		}
+	}
+}
+
+func TestSubuserDomainSet(t *testing.T) {
+	domains := schema.NewSet(schema.HashSchema(&schema.Schema{Type: schema.TypeString}), []interface{}{"1", "2"})
+	empty := schema.NewSet(schema.HashString, nil)
+
+	// Switching from domains = [1, 2] to domain = 1 must only remove 2
+	removed := subuserDomainSet(defaultDomainID, domains).Difference(subuserDomainSet("1", empty)).List()
+	if len(removed) != 1 || removed[0] != "2" {
+		t.Errorf("removed domains = %v, want [2]", removed)
+	}
+
+	if got := subuserDomainSet(defaultDomainID, empty).Len(); got != 0 {
+		t.Errorf("subuserDomainSet of the default domain has %d domains, want 0", got)
+	}
+}
+
+func testResourceSubuserSuppliedPasswordConfig(username, password string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_subuser" "test" {
+	username = "%[1]s"
+	email    = "%[1]s@example.org"
+	password {
+		value = "%[2]s"
+	}
+
+	ips = %[3]s
+}`, username, password, testIPsRaw)
+}
+
 func testResourceSubuserCreateConfig(username, passwordDestination string, disabled bool) string {
 	return fmt.Sprintf(`
 resource "sendgrid_subuser" "test" {
@@ -74,6 +274,46 @@ resource "sendgrid_subuser" "test" {
 }`, username, passwordDestination, disabled, testIPsRaw)
 }
 
+func testResourceSubuserWebsiteAccessConfig(username, passwordDestination string, websiteAccessDisabled bool) string {
+	return fmt.Sprintf(`
+resource "sendgrid_subuser" "test" {
+	username = "%[1]s"
+	email    = "%[1]s@example.org"
+	password {
+		destination = "%[2]s"
+	}
+
+	disabled                = true
+	website_access_disabled = %[3]t
+
+	ips = %[4]s
+}`, username, passwordDestination, websiteAccessDisabled, testIPsRaw)
+}
+
+func testResourceSubuserCheckWebsiteAccess(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		disabled, err := getWebsiteAccessDisabled(apiKey, resourceState.Primary.ID)
+		if err == errWebsiteAccessUnsupported {
+			// The account doesn't report website access, so there is nothing to compare
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading website access: %w", err)
+		}
+
+		if fmt.Sprintf("%t", disabled) != resourceState.Primary.Attributes[keyWebsiteAccessDisabled] {
+			return fmt.Errorf("website access disabled does not match")
+		}
+
+		return nil
+	}
+}
+
 func testResourceSubuserCheckSendgrid(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		resourceState := s.Modules[0].Resources[resourceName]
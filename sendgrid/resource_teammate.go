@@ -0,0 +1,317 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyIsAdmin = "is_admin"
+	keyPending = "pending"
+	keyToken   = "token"
+)
+
+// Scopes are dot-separated, e.g. "mail.send" or "templates.versions.read"
+var teammateScopePattern = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)*$`)
+
+var (
+	createTeammateRate = time.Tick(5 * time.Second)
+)
+
+type teammate struct {
+	Username string        `json:"username"`
+	Email    string        `json:"email"`
+	IsAdmin  bool          `json:"is_admin"`
+	Scopes   []interface{} `json:"scopes"`
+	Token    string        `json:"token"`
+	Pending  bool          `json:"-"`
+}
+
+func resourceTeammate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTeammateCreate,
+		Read:   resourceTeammateRead,
+		Update: resourceTeammateUpdate,
+		Delete: resourceTeammateDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyEmail: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyScopes: &schema.Schema{
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          teammateScopeSchema(),
+				ConflictsWith: []string{keyIsAdmin},
+			},
+			keyIsAdmin: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyUsername: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyPending: &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTeammateCreate(d *schema.ResourceData, m interface{}) error {
+	email := d.Get(keyEmail).(string)
+
+	err := inviteTeammate(m.(*Config).APIKey, d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(email)
+
+	return resourceTeammateRead(d, m)
+}
+
+func resourceTeammateRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	mate, err := getTeammate(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get teammate")
+	} else if mate == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyEmail, mate.Email)
+	d.Set(keyIsAdmin, mate.IsAdmin)
+	d.Set(keyUsername, mate.Username)
+	d.Set(keyPending, mate.Pending)
+
+	// Admins implicitly have every scope
+	if !mate.IsAdmin {
+		d.Set(keyScopes, mate.Scopes)
+	}
+
+	return nil
+}
+
+func resourceTeammateUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	mate, err := getTeammate(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get teammate")
+	} else if mate == nil {
+		return fmt.Errorf("teammate %s no longer exists", d.Id())
+	}
+
+	// Pending invitations can't be changed, so they are sent again instead
+	if mate.Pending {
+		err = deletePendingTeammate(config.APIKey, mate.Token)
+		if err != nil {
+			return err
+		}
+
+		err = inviteTeammate(config.APIKey, d)
+		if err != nil {
+			return err
+		}
+
+		return resourceTeammateRead(d, m)
+	}
+
+	data, err := json.Marshal(teammatePayload(d))
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(config.APIKey, "/v3/teammates/"+url.PathEscape(mate.Username), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update teammate")
+	}
+
+	return resourceTeammateRead(d, m)
+}
+
+func resourceTeammateDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	mate, err := getTeammate(config.APIKey, d.Id())
+	if err != nil {
+		return errors.Wrap(err, "failed to get teammate")
+	} else if mate == nil {
+		return nil
+	}
+
+	if mate.Pending {
+		return deletePendingTeammate(config.APIKey, mate.Token)
+	}
+
+	request := sendgrid.GetRequest(config.APIKey, "/v3/teammates/"+url.PathEscape(mate.Username), sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete teammate")
+}
+
+func teammatePayload(d *schema.ResourceData) map[string]interface{} {
+	isAdmin := d.Get(keyIsAdmin).(bool)
+
+	scopes := []interface{}{}
+	if !isAdmin {
+		scopes = d.Get(keyScopes).(*schema.Set).List()
+	}
+
+	return map[string]interface{}{
+		"is_admin": isAdmin,
+		"scopes":   scopes,
+	}
+}
+
+func inviteTeammate(apiKey string, d *schema.ResourceData) error {
+	payload := teammatePayload(d)
+	if !payload["is_admin"].(bool) && len(payload["scopes"].([]interface{})) == 0 {
+		return fmt.Errorf("a teammate must either have %s or be given %s", keyScopes, keyIsAdmin)
+	}
+
+	payload["email"] = d.Get(keyEmail).(string)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request := sendgrid.GetRequest(apiKey, "/v3/teammates", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+
+	_, err = doRequest(request, withStatus(http.StatusCreated), withRateLimit(createTeammateRate))
+	if err != nil {
+		return errors.Wrap(err, "failed to invite teammate")
+	}
+
+	return nil
+}
+
+func deletePendingTeammate(apiKey, token string) error {
+	request := sendgrid.GetRequest(apiKey, "/v3/teammates/pending/"+token, sendgridAddress)
+	request.Method = http.MethodDelete
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete pending teammate")
+}
+
+// getTeammate finds a teammate by email, first among those who accepted their
+// invitation and then among pending invitations.
+func getTeammate(apiKey, email string) (*teammate, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/teammates", sendgridAddress)
+	request.Method = http.MethodGet
+	request.QueryParams = map[string]string{"limit": "500"}
+
+	res, err := doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query teammates")
+	}
+
+	data := struct {
+		Result []teammate `json:"result"`
+	}{}
+
+	err = json.Unmarshal([]byte(res.Body), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal teammates query response")
+	}
+
+	for _, mate := range data.Result {
+		if mate.Email == email {
+			// The listing doesn't include scopes
+			return getTeammateByUsername(apiKey, mate.Username)
+		}
+	}
+
+	request = sendgrid.GetRequest(apiKey, "/v3/teammates/pending", sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query pending teammates")
+	}
+
+	data.Result = nil
+	err = json.Unmarshal([]byte(res.Body), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal pending teammates query response")
+	}
+
+	for _, mate := range data.Result {
+		if mate.Email == email {
+			mate.Pending = true
+			return &mate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func getTeammateByUsername(apiKey, username string) (*teammate, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/teammates/"+url.PathEscape(username), sendgridAddress)
+	request.Method = http.MethodGet
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query teammate")
+	}
+
+	var mate teammate
+	err = json.Unmarshal([]byte(res.Body), &mate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal teammate query response")
+	}
+
+	return &mate, nil
+}
+
+func teammateScopeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		ValidateFunc: validateTeammateScope,
+	}
+}
+
+func validateTeammateScope(v interface{}, k string) ([]string, []error) {
+	scope := v.(string)
+	if !teammateScopePattern.MatchString(scope) {
+		return nil, []error{fmt.Errorf("%s is not a valid scope: %s", k, scope)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,88 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceTeammate(t *testing.T) {
+	email := acctest.RandomWithPrefix("tf-sg-test-teammate") + "@example.org"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceTeammateConfig(t, email, []string{"mail.send"}),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTeammateCheckSendgrid("sendgrid_teammate.test"),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "id", email),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "email", email),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "is_admin", "false"),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "pending", "true"),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "scopes.#", "1"),
+				),
+			},
+			{
+				Config: testResourceTeammateConfig(t, email, []string{"mail.send", "templates.read"}),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTeammateCheckSendgrid("sendgrid_teammate.test"),
+					resource.TestCheckResourceAttr("sendgrid_teammate.test", "scopes.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_teammate.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceTeammateConfig(t *testing.T, email string, scopes []string) string {
+	scopesBytes, err := json.Marshal(scopes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fmt.Sprintf(`
+resource "sendgrid_teammate" "test" {
+	email  = "%s"
+	scopes = %s
+}`, email, string(scopesBytes))
+}
+
+func testResourceTeammateCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		mate, err := getTeammate(apiKey, instanceState.ID)
+		if err != nil {
+			return fmt.Errorf("error reading teammate: %w", err)
+		}
+
+		if mate == nil {
+			return fmt.Errorf("teammate not found")
+		}
+
+		if fmt.Sprintf("%d", len(mate.Scopes)) != instanceState.Attributes["scopes.#"] {
+			return fmt.Errorf("mate.Scopes length does not match state")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,229 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyGeneration = "generation"
+	keyUpdatedAt  = "updated_at"
+
+	generationDynamic = "dynamic"
+	generationLegacy  = "legacy"
+
+	templatesPageSize = 200
+)
+
+type template struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Generation string            `json:"generation"`
+	UpdatedAt  string            `json:"updated_at"`
+	Versions   []templateVersion `json:"versions"`
+}
+
+func resourceTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTemplateCreate,
+		Read:   resourceTemplateRead,
+		Update: resourceTemplateUpdate,
+		Delete: resourceTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				realID, onBehalfOf, err := parseOnBehalfOfImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(realID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyName: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			keyGeneration: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      generationDynamic,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{generationDynamic, generationLegacy}, false),
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keyUpdatedAt: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"name":       d.Get(keyName).(string),
+		"generation": d.Get(keyGeneration).(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/templates", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create template")
+	}
+
+	var t template
+	err = json.Unmarshal([]byte(res.Body), &t)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created template")
+	}
+
+	d.SetId(t.ID)
+
+	return resourceTemplateRead(d, m)
+}
+
+func resourceTemplateRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	t, err := getTemplate(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get template")
+	} else if t == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyName, t.Name)
+	d.Set(keyGeneration, t.Generation)
+	d.Set(keyUpdatedAt, t.UpdatedAt)
+
+	return nil
+}
+
+func resourceTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"name": d.Get(keyName).(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/templates/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update template")
+	}
+
+	return resourceTemplateRead(d, m)
+}
+
+func resourceTemplateDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/templates/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete template")
+}
+
+func getTemplate(apiKey, id, onBehalfOf string) (*template, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/templates/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query template")
+	}
+
+	var t template
+	err = json.Unmarshal([]byte(res.Body), &t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal template query response")
+	}
+
+	return &t, nil
+}
+
+// listTemplates gets every template of the given generations, following the
+// page tokens of the paginated listing.
+func listTemplates(apiKey string, generations []string, onBehalfOf string) ([]template, error) {
+	var templates []template
+
+	pageToken := ""
+	for {
+		request := sendgrid.GetRequest(apiKey, "/v3/templates", sendgridAddress)
+		request.Method = http.MethodGet
+		request.QueryParams = map[string]string{
+			"generations": strings.Join(generations, ","),
+			"page_size":   strconv.Itoa(templatesPageSize),
+		}
+		if pageToken != "" {
+			request.QueryParams["page_token"] = pageToken
+		}
+		setOnBehalfOf(request, onBehalfOf)
+
+		res, err := doRequest(request, withStatus(http.StatusOK))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query templates")
+		}
+
+		var page struct {
+			Result   []template `json:"result"`
+			Metadata struct {
+				Next string `json:"next"`
+			} `json:"_metadata"`
+		}
+		err = json.Unmarshal([]byte(res.Body), &page)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal template query response")
+		}
+
+		templates = append(templates, page.Result...)
+
+		next, err := url.Parse(page.Metadata.Next)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse next page of templates")
+		}
+
+		pageToken = next.Query().Get("page_token")
+		if len(page.Result) == 0 || pageToken == "" {
+			return templates, nil
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keySourceTemplateID        = "source_template_id"
+	keySourceVersionID         = "source_version_id"
+	keySourceOnBehalfOf        = "source_on_behalf_of"
+	keyPromotedSourceVersionID = "promoted_source_version_id"
+	keyPromotedUpdatedAt       = "promoted_updated_at"
+
+	sourceVersionActive = "active"
+)
+
+func resourceTemplatePromotion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTemplatePromotionCreate,
+		Read:   resourceTemplatePromotionRead,
+		Update: resourceTemplatePromotionUpdate,
+		Delete: resourceTemplatePromotionDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				templateID, versionID, onBehalfOf, err := parseTemplateVersionImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				// The source can't be known from the destination version, so
+				// it is left for the next apply to resolve and promote.
+				d.Set(keyTemplateID, templateID)
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(versionID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		CustomizeDiff: func(d *schema.ResourceDiff, m interface{}) error {
+			// The source version is not part of the configuration, so look it
+			// up and only promote again when it has changed.
+			if d.Id() == "" {
+				return nil
+			}
+
+			for _, k := range []string{keySourceTemplateID, keySourceVersionID, keySourceOnBehalfOf} {
+				if !d.NewValueKnown(k) {
+					return d.SetNewComputed(keyPromotedSourceVersionID)
+				}
+			}
+
+			config := m.(*Config)
+			source, err := resolveTemplateVersion(config.APIKey, d.Get(keySourceTemplateID).(string), d.Get(keySourceVersionID).(string), d.Get(keySourceOnBehalfOf).(string))
+			if err != nil {
+				return err
+			}
+
+			if source.ID != d.Get(keyPromotedSourceVersionID).(string) {
+				if err := d.SetNew(keyPromotedSourceVersionID, source.ID); err != nil {
+					return err
+				}
+			}
+
+			if source.UpdatedAt != d.Get(keyPromotedUpdatedAt).(string) {
+				return d.SetNew(keyPromotedUpdatedAt, source.UpdatedAt)
+			}
+
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			keySourceTemplateID: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keySourceVersionID: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  sourceVersionActive,
+			},
+			keySourceOnBehalfOf: &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			keyTemplateID: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keyActive: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			keyPromotedSourceVersionID: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyPromotedUpdatedAt: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// promoteTemplateVersion copies the source version into the destination
+// template, creating a new version or updating the one already promoted.
+func promoteTemplateVersion(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	source, err := resolveTemplateVersion(config.APIKey, d.Get(keySourceTemplateID).(string), d.Get(keySourceVersionID).(string), d.Get(keySourceOnBehalfOf).(string))
+	if err != nil {
+		return err
+	}
+
+	active := 0
+	if d.Get(keyActive).(bool) {
+		active = 1
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"name":                   source.Name,
+		"subject":                source.Subject,
+		"html_content":           source.HTMLContent,
+		"plain_content":          source.PlainContent,
+		"generate_plain_content": source.GeneratePlainContent,
+		"editor":                 source.Editor,
+		"test_data":              source.TestData,
+		"active":                 active,
+	})
+	if err != nil {
+		return err
+	}
+
+	templateID := d.Get(keyTemplateID).(string)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/templates/"+templateID+"/versions", sendgridAddress)
+	request.Method = http.MethodPost
+	status := http.StatusCreated
+	if d.Id() != "" {
+		request = sendgrid.GetRequest(config.APIKey, templateVersionURI(templateID, d.Id()), sendgridAddress)
+		request.Method = http.MethodPatch
+		status = http.StatusOK
+	}
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(status))
+	if err != nil {
+		return errors.Wrap(err, "failed to promote template version")
+	}
+
+	if d.Id() == "" {
+		var version templateVersion
+		err = json.Unmarshal([]byte(res.Body), &version)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal promoted template version")
+		}
+
+		d.SetId(version.ID)
+	}
+
+	d.Set(keyPromotedSourceVersionID, source.ID)
+	d.Set(keyPromotedUpdatedAt, source.UpdatedAt)
+
+	return nil
+}
+
+func resourceTemplatePromotionCreate(d *schema.ResourceData, m interface{}) error {
+	if err := promoteTemplateVersion(d, m); err != nil {
+		return err
+	}
+
+	return resourceTemplatePromotionRead(d, m)
+}
+
+func resourceTemplatePromotionRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	templateID := d.Get(keyTemplateID).(string)
+	t, err := getTemplate(config.APIKey, templateID, d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get destination template")
+	} else if t == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// Activating another version of the destination template deactivates
+	// the promoted one, which shows up as a diff on active.
+	found := false
+	activeID := ""
+	for _, v := range t.Versions {
+		if v.ID == d.Id() {
+			found = true
+		}
+
+		if v.Active == 1 {
+			activeID = v.ID
+		}
+	}
+
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyTemplateID, templateID)
+	d.Set(keyActive, activeID == d.Id())
+
+	return nil
+}
+
+func resourceTemplatePromotionUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := promoteTemplateVersion(d, m); err != nil {
+		return err
+	}
+
+	return resourceTemplatePromotionRead(d, m)
+}
+
+func resourceTemplatePromotionDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, templateVersionURI(d.Get(keyTemplateID).(string), d.Id()), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete promoted template version")
+}
+
+// resolveTemplateVersion gets a template version, where versionID may be
+// "active" to get the template's active version.
+func resolveTemplateVersion(apiKey, templateID, versionID, onBehalfOf string) (*templateVersion, error) {
+	if versionID == sourceVersionActive {
+		t, err := getTemplate(apiKey, templateID, onBehalfOf)
+		if err != nil {
+			return nil, err
+		} else if t == nil {
+			return nil, fmt.Errorf("template %s not found", templateID)
+		}
+
+		versionID = ""
+		for _, v := range t.Versions {
+			if v.Active == 1 {
+				versionID = v.ID
+			}
+		}
+
+		if versionID == "" {
+			return nil, fmt.Errorf("template %s has no active version", templateID)
+		}
+	}
+
+	version, err := getTemplateVersion(apiKey, templateID, versionID, onBehalfOf)
+	if err != nil {
+		return nil, err
+	} else if version == nil {
+		return nil, fmt.Errorf("version %s of template %s not found", versionID, templateID)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,124 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceTemplatePromotion(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-template")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceTemplatePromotionConfig(name, "Welcome {{name}}"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplatePromotionCheckSendgrid("sendgrid_template_promotion.test"),
+					resource.TestCheckResourceAttrPair("sendgrid_template_promotion.test", "promoted_source_version_id", "sendgrid_template_version.staging", "id"),
+				),
+			},
+			{
+				Config: testResourceTemplatePromotionConfig(name, "Hello {{name}}"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplatePromotionCheckSendgrid("sendgrid_template_promotion.test"),
+				),
+			},
+			{
+				// Activating another destination version must be detected
+				Config:             testResourceTemplatePromotionConfig(name, "Hello {{name}}") + testResourceTemplatePromotionHotfixConfig(name),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testResourceTemplatePromotionConfig(name, "Hello {{name}}"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplatePromotionCheckSendgrid("sendgrid_template_promotion.test"),
+					resource.TestCheckResourceAttr("sendgrid_template_promotion.test", "active", "true"),
+				),
+			},
+			{
+				ResourceName:            "sendgrid_template_promotion.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testResourceTemplateVersionImportID("sendgrid_template_promotion.test"),
+				ImportStateVerifyIgnore: []string{"source_template_id", "source_version_id", "source_on_behalf_of", "promoted_source_version_id", "promoted_updated_at"},
+			},
+		},
+	})
+}
+
+func testResourceTemplatePromotionConfig(name, subject string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "staging" {
+	name = "%s-staging"
+}
+
+resource "sendgrid_template_version" "staging" {
+	template_id  = sendgrid_template.staging.id
+	name         = "%s"
+	subject      = "%s"
+	html_content = "<p>%s</p>"
+}
+
+resource "sendgrid_template" "production" {
+	name = "%s-production"
+}
+
+resource "sendgrid_template_promotion" "test" {
+	source_template_id = sendgrid_template_version.staging.template_id
+	template_id        = sendgrid_template.production.id
+}`, name, name, subject, subject, name)
+}
+
+func testResourceTemplatePromotionHotfixConfig(name string) string {
+	return fmt.Sprintf(`
+
+resource "sendgrid_template_version" "hotfix" {
+	template_id  = sendgrid_template.production.id
+	name         = "%s-hotfix"
+	subject      = "Hotfix"
+	html_content = "<p>Hotfix</p>"
+
+	depends_on = [sendgrid_template_promotion.test]
+}`, name)
+}
+
+func testResourceTemplatePromotionCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		version, err := getTemplateVersion(apiKey, instanceState.Attributes["template_id"], instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading promoted template version: %w", err)
+		}
+
+		if version == nil {
+			return fmt.Errorf("promoted template version not found")
+		}
+
+		source, err := resolveTemplateVersion(apiKey, instanceState.Attributes["source_template_id"], "active", "")
+		if err != nil {
+			return fmt.Errorf("error reading source template version: %w", err)
+		}
+
+		if version.Subject != source.Subject || version.HTMLContent != source.HTMLContent {
+			return fmt.Errorf("promoted version does not match source version")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceTemplate(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-template")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceTemplateConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplateCheckSendgrid("sendgrid_template.test"),
+					resource.TestCheckResourceAttr("sendgrid_template.test", "name", name),
+					resource.TestCheckResourceAttr("sendgrid_template.test", "generation", "dynamic"),
+				),
+			},
+			{
+				Config: testResourceTemplateConfig(name + "-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplateCheckSendgrid("sendgrid_template.test"),
+					resource.TestCheckResourceAttr("sendgrid_template.test", "name", name+"-renamed"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceTemplateConfig(name string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	name = "%s"
+}`, name)
+}
+
+func testResourceTemplateCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		template, err := getTemplate(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading template: %w", err)
+		}
+
+		if template == nil {
+			return fmt.Errorf("template not found")
+		}
+
+		if template.Name != instanceState.Attributes["name"] {
+			return fmt.Errorf("template.Name does not match state")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,423 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyTemplateID         = "template_id"
+	keySubject            = "subject"
+	keyHTMLContent        = "html_content"
+	keyHTMLContentFile    = "html_content_file"
+	keyPlainContent       = "plain_content"
+	keyPlainContentFile   = "plain_content_file"
+	keyEditor             = "editor"
+	keyTestData           = "test_data"
+	keyActive             = "active"
+	keyValidateHandlebars = "validate_handlebars"
+	keyRenderTestData     = "render_test_data"
+
+	editorCode   = "code"
+	editorDesign = "design"
+)
+
+type templateVersion struct {
+	ID                   string `json:"id"`
+	TemplateID           string `json:"template_id"`
+	Active               int    `json:"active"`
+	Name                 string `json:"name"`
+	Subject              string `json:"subject"`
+	HTMLContent          string `json:"html_content"`
+	PlainContent         string `json:"plain_content"`
+	GeneratePlainContent bool   `json:"generate_plain_content"`
+	Editor               string `json:"editor"`
+	TestData             string `json:"test_data"`
+	UpdatedAt            string `json:"updated_at"`
+}
+
+func resourceTemplateVersion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTemplateVersionCreate,
+		Read:   resourceTemplateVersionRead,
+		Update: resourceTemplateVersionUpdate,
+		Delete: resourceTemplateVersionDelete,
+		CustomizeDiff: func(d *schema.ResourceDiff, m interface{}) error {
+			// Content loaded from a file does not change the configuration, so
+			// compare the normalized contents of the file with the state.
+			for contentKey, fileKey := range map[string]string{
+				keyHTMLContent:  keyHTMLContentFile,
+				keyPlainContent: keyPlainContentFile,
+			} {
+				path := d.Get(fileKey).(string)
+				if path == "" {
+					continue
+				}
+
+				content, err := readTemplateContentFile(path)
+				if err != nil {
+					return err
+				}
+
+				if normalizeTemplateContent(content) != normalizeTemplateContent(d.Get(contentKey).(string)) {
+					if err := d.SetNew(contentKey, content); err != nil {
+						return err
+					}
+				}
+			}
+
+			return validateTemplateVersionDiff(d, m)
+		},
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				templateID, versionID, onBehalfOf, err := parseTemplateVersionImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyTemplateID, templateID)
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.Set(keyValidateHandlebars, true)
+				d.Set(keyRenderTestData, false)
+				d.SetId(versionID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyTemplateID: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyName: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			keySubject: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyHTMLContent: &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{keyHTMLContentFile},
+				DiffSuppressFunc: suppressTemplateContentDiff,
+			},
+			keyHTMLContentFile: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{keyHTMLContent},
+			},
+			keyPlainContent: &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{keyPlainContentFile},
+				DiffSuppressFunc: suppressTemplateContentDiff,
+			},
+			keyPlainContentFile: &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{keyPlainContent},
+			},
+			keyEditor: &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      editorCode,
+				ValidateFunc: validation.StringInSlice([]string{editorCode, editorDesign}, false),
+			},
+			keyTestData: &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: suppressJSONDiff,
+			},
+			keyActive: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			keyValidateHandlebars: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			keyRenderTestData: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keyUpdatedAt: &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func templateVersionPayload(d *schema.ResourceData) map[string]interface{} {
+	active := 0
+	if d.Get(keyActive).(bool) {
+		active = 1
+	}
+
+	// Only supplied plain content is kept in state, so an empty value means
+	// it is generated from the HTML content.
+	plainContent := d.Get(keyPlainContent).(string)
+
+	return map[string]interface{}{
+		"name":                   d.Get(keyName).(string),
+		"subject":                d.Get(keySubject).(string),
+		"html_content":           d.Get(keyHTMLContent).(string),
+		"plain_content":          plainContent,
+		"generate_plain_content": plainContent == "",
+		"editor":                 d.Get(keyEditor).(string),
+		"test_data":              d.Get(keyTestData).(string),
+		"active":                 active,
+	}
+}
+
+func resourceTemplateVersionCreate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(templateVersionPayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	templateID := d.Get(keyTemplateID).(string)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/templates/"+templateID+"/versions", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create template version")
+	}
+
+	var version templateVersion
+	err = json.Unmarshal([]byte(res.Body), &version)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created template version")
+	}
+
+	d.SetId(version.ID)
+
+	return resourceTemplateVersionRead(d, m)
+}
+
+func resourceTemplateVersionRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	version, err := getTemplateVersion(config.APIKey, d.Get(keyTemplateID).(string), d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get template version")
+	} else if version == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyName, version.Name)
+	d.Set(keySubject, version.Subject)
+	d.Set(keyHTMLContent, version.HTMLContent)
+	// Generated plain content is kept out of state so that it isn't sent back
+	// as supplied content, which would stop it being regenerated.
+	if version.GeneratePlainContent {
+		d.Set(keyPlainContent, "")
+	} else {
+		d.Set(keyPlainContent, version.PlainContent)
+	}
+	d.Set(keyEditor, version.Editor)
+	d.Set(keyTestData, version.TestData)
+	d.Set(keyActive, version.Active == 1)
+	d.Set(keyUpdatedAt, version.UpdatedAt)
+
+	return nil
+}
+
+func resourceTemplateVersionUpdate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(templateVersionPayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, templateVersionURI(d.Get(keyTemplateID).(string), d.Id()), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	_, err = doRequest(request, withStatus(http.StatusOK))
+	if err != nil {
+		return errors.Wrap(err, "failed to update template version")
+	}
+
+	return resourceTemplateVersionRead(d, m)
+}
+
+func resourceTemplateVersionDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, templateVersionURI(d.Get(keyTemplateID).(string), d.Id()), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete template version")
+}
+
+// validateTemplateVersionDiff parses the handlebars in changed content, since
+// Sendgrid only reports syntax errors when an email is sent. Versions of legacy
+// templates use substitution tags rather than handlebars and are skipped.
+func validateTemplateVersionDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get(keyValidateHandlebars).(bool) {
+		return nil
+	}
+
+	contentKeys := []string{keySubject, keyHTMLContent, keyPlainContent}
+
+	changed := d.Id() == ""
+	for _, k := range append(contentKeys, keyTestData, keyValidateHandlebars, keyRenderTestData) {
+		changed = changed || d.HasChange(k)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	// A template created in the same apply has no ID yet; validate its
+	// versions as dynamic, which is the default generation.
+	if d.NewValueKnown(keyTemplateID) {
+		config := m.(*Config)
+		t, err := getTemplate(config.APIKey, d.Get(keyTemplateID).(string), d.Get(keyOnBehalfOf).(string))
+		if err != nil {
+			return errors.Wrap(err, "failed to get template")
+		} else if t != nil && t.Generation == generationLegacy {
+			return nil
+		}
+	}
+
+	var data interface{}
+	render := d.Get(keyRenderTestData).(bool)
+	if render {
+		if !d.NewValueKnown(keyTestData) {
+			render = false
+		} else if testData := d.Get(keyTestData).(string); testData == "" {
+			return fmt.Errorf("%s requires %s", keyRenderTestData, keyTestData)
+		} else if err := json.Unmarshal([]byte(testData), &data); err != nil {
+			return errors.Wrapf(err, "failed to parse %s", keyTestData)
+		}
+	}
+
+	for _, k := range contentKeys {
+		if !d.NewValueKnown(k) {
+			continue
+		}
+
+		nodes, err := parseHandlebars(d.Get(k).(string))
+		if err != nil {
+			return fmt.Errorf("invalid handlebars in %s: %s", k, err)
+		}
+
+		if render {
+			if err := renderHandlebars(nodes, data); err != nil {
+				return fmt.Errorf("failed to render %s: %s", k, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func templateVersionURI(templateID, versionID string) string {
+	return "/v3/templates/" + templateID + "/versions/" + versionID
+}
+
+func getTemplateVersion(apiKey, templateID, versionID, onBehalfOf string) (*templateVersion, error) {
+	request := sendgrid.GetRequest(apiKey, templateVersionURI(templateID, versionID), sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query template version")
+	}
+
+	var version templateVersion
+	err = json.Unmarshal([]byte(res.Body), &version)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal template version query response")
+	}
+
+	return &version, nil
+}
+
+func parseTemplateVersionImportID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%s), expected template_id:version_id:on_behalf_of", id)
+	}
+
+	if len(parts) == 2 {
+		return parts[0], parts[1], "", nil
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func readTemplateContentFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read template content file")
+	}
+
+	return string(data), nil
+}
+
+// normalizeTemplateContent ignores line endings and trailing whitespace, which
+// Sendgrid does not preserve.
+func normalizeTemplateContent(content string) string {
+	lines := strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func suppressTemplateContentDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeTemplateContent(old) == normalizeTemplateContent(new)
+}
+
+func suppressJSONDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+
+	var oldValue, newValue interface{}
+	if json.Unmarshal([]byte(old), &oldValue) != nil || json.Unmarshal([]byte(new), &newValue) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
@@ -0,0 +1,255 @@
+package sendgrid
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceTemplateVersion(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-template")
+	dir, err := ioutil.TempDir("", "tf-sg-test-template")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	htmlFile := filepath.Join(dir, "welcome.html")
+
+	writeHTML := func(content string) func() {
+		return func() {
+			if err := ioutil.WriteFile(htmlFile, []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeHTML("<p>Hello {{name}}</p>\r\n"),
+				Config:    testResourceTemplateVersionConfig(name, htmlFile),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplateVersionCheckSendgrid("sendgrid_template_version.test"),
+					resource.TestCheckResourceAttr("sendgrid_template_version.test", "active", "true"),
+					resource.TestCheckResourceAttr("sendgrid_template_version.test", "subject", "Welcome {{name}}"),
+				),
+			},
+			{
+				PreConfig: writeHTML("<p>Welcome {{name}}</p>\n"),
+				Config:    testResourceTemplateVersionConfig(name, htmlFile),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplateVersionCheckSendgrid("sendgrid_template_version.test"),
+					resource.TestCheckResourceAttr("sendgrid_template_version.test", "html_content", "<p>Welcome {{name}}</p>\n"),
+				),
+			},
+			{
+				ResourceName:            "sendgrid_template_version.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testResourceTemplateVersionImportID("sendgrid_template_version.test"),
+				ImportStateVerifyIgnore: []string{"html_content_file"},
+			},
+		},
+	})
+}
+
+func TestNormalizeTemplateContent(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"<p>hi</p>", "<p>hi</p>\n", true},
+		{"<p>hi</p>  \r\n<p>there</p>", "<p>hi</p>\n<p>there</p>", true},
+		{"<p>hi</p>", "<p> hi</p>", false},
+	}
+
+	for _, test := range tests {
+		if equal := normalizeTemplateContent(test.a) == normalizeTemplateContent(test.b); equal != test.equal {
+			t.Errorf("normalizeTemplateContent(%q) == normalizeTemplateContent(%q) is %t, want %t", test.a, test.b, equal, test.equal)
+		}
+	}
+}
+
+func TestSuppressJSONDiff(t *testing.T) {
+	if !suppressJSONDiff("", `{"a": 1, "b": 2}`, `{"b":2,"a":1}`, nil) {
+		t.Error("expected equivalent JSON to be suppressed")
+	}
+
+	if suppressJSONDiff("", `{"a": 1}`, `{"a": 2}`, nil) {
+		t.Error("expected different JSON not to be suppressed")
+	}
+}
+
+func TestAccResourceTemplateVersionGeneratedPlainContent(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-template")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceTemplateVersionGeneratedConfig(name, "Hello"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_template_version.test", "plain_content", ""),
+					testResourceTemplateVersionCheckPlainContent("sendgrid_template_version.test", "Hello"),
+				),
+			},
+			{
+				// Changing the HTML content must regenerate the plain content
+				Config: testResourceTemplateVersionGeneratedConfig(name, "Goodbye"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sendgrid_template_version.test", "plain_content", ""),
+					testResourceTemplateVersionCheckPlainContent("sendgrid_template_version.test", "Goodbye"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTemplateVersionLegacy(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test-template")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceTemplateVersionLegacyConfig(name, false),
+			},
+			{
+				// Legacy content isn't handlebars, so unknown helpers are not reported
+				Config: testResourceTemplateVersionLegacyConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceTemplateVersionCheckSendgrid("sendgrid_template_version.test"),
+				),
+			},
+		},
+	})
+}
+
+func testResourceTemplateVersionConfig(name, htmlFile string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	name = "%s"
+}
+
+resource "sendgrid_template_version" "test" {
+	template_id       = sendgrid_template.test.id
+	name              = "%s"
+	subject           = "Welcome {{name}}"
+	html_content_file = "%s"
+	plain_content     = "Welcome {{name}}"
+	test_data         = jsonencode({ name = "Jane" })
+}`, name, name, htmlFile)
+}
+
+func testResourceTemplateVersionGeneratedConfig(name, text string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	name = "%[1]s"
+}
+
+resource "sendgrid_template_version" "test" {
+	template_id  = sendgrid_template.test.id
+	name         = "%[1]s"
+	subject      = "%[2]s"
+	html_content = "<p>%[2]s</p>"
+}`, name, text)
+}
+
+func testResourceTemplateVersionCheckPlainContent(resourceName, text string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		version, err := getTemplateVersion(apiKey, resourceState.Primary.Attributes["template_id"], resourceState.Primary.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading template version: %w", err)
+		}
+
+		if version == nil {
+			return fmt.Errorf("template version not found")
+		}
+
+		if !version.GeneratePlainContent || !strings.Contains(version.PlainContent, text) {
+			return fmt.Errorf("plain content was not generated from the HTML content: %q", version.PlainContent)
+		}
+
+		return nil
+	}
+}
+
+func testResourceTemplateVersionLegacyConfig(name string, withVersion bool) string {
+	config := fmt.Sprintf(`
+resource "sendgrid_template" "test" {
+	name       = "%s"
+	generation = "legacy"
+}`, name)
+
+	if !withVersion {
+		return config
+	}
+
+	return config + fmt.Sprintf(`
+
+resource "sendgrid_template_version" "test" {
+	template_id  = sendgrid_template.test.id
+	name         = "%s"
+	subject      = "<%%subject%%>"
+	html_content = "<%%body%%> {{uppercase name}}"
+}`, name)
+}
+
+func testResourceTemplateVersionImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return "", fmt.Errorf("resource not found in state")
+		}
+
+		return resourceState.Primary.Attributes["template_id"] + ":" + resourceState.Primary.ID, nil
+	}
+}
+
+func testResourceTemplateVersionCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		version, err := getTemplateVersion(apiKey, instanceState.Attributes["template_id"], instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading template version: %w", err)
+		}
+
+		if version == nil {
+			return fmt.Errorf("template version not found")
+		}
+
+		if normalizeTemplateContent(version.HTMLContent) != normalizeTemplateContent(instanceState.Attributes["html_content"]) {
+			return fmt.Errorf("version.HTMLContent does not match state")
+		}
+
+		return nil
+	}
+}
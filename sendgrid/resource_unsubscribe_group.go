@@ -0,0 +1,189 @@
+package sendgrid
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+)
+
+const (
+	keyDescription  = "description"
+	keyIsDefault    = "is_default"
+	keyGroupID      = "group_id"
+	keyUnsubscribes = "unsubscribes"
+)
+
+type unsubscribeGroup struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	IsDefault    bool   `json:"is_default"`
+	Unsubscribes int    `json:"unsubscribes"`
+}
+
+func resourceUnsubscribeGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUnsubscribeGroupCreate,
+		Read:   resourceUnsubscribeGroupRead,
+		Update: resourceUnsubscribeGroupUpdate,
+		Delete: resourceUnsubscribeGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				realID, onBehalfOf, err := parseOnBehalfOfImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.Set(keyOnBehalfOf, onBehalfOf)
+				d.SetId(realID)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyName: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 30),
+			},
+			keyDescription: &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			keyIsDefault: &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyOnBehalfOf: onBehalfOfSchema(),
+			keyGroupID: &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			keyUnsubscribes: &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func unsubscribeGroupPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        d.Get(keyName).(string),
+		"description": d.Get(keyDescription).(string),
+		"is_default":  d.Get(keyIsDefault).(bool),
+	}
+}
+
+func resourceUnsubscribeGroupCreate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(unsubscribeGroupPayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/asm/groups", sendgridAddress)
+	request.Method = http.MethodPost
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to create unsubscribe group")
+	}
+
+	var group unsubscribeGroup
+	err = json.Unmarshal([]byte(res.Body), &group)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal created unsubscribe group")
+	}
+
+	d.SetId(strconv.FormatInt(group.ID, 10))
+
+	return resourceUnsubscribeGroupRead(d, m)
+}
+
+func resourceUnsubscribeGroupRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	group, err := getUnsubscribeGroup(config.APIKey, d.Id(), d.Get(keyOnBehalfOf).(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to get unsubscribe group")
+	} else if group == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(keyName, group.Name)
+	d.Set(keyDescription, group.Description)
+	d.Set(keyIsDefault, group.IsDefault)
+	d.Set(keyGroupID, group.ID)
+	d.Set(keyUnsubscribes, group.Unsubscribes)
+
+	return nil
+}
+
+func resourceUnsubscribeGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	data, err := json.Marshal(unsubscribeGroupPayload(d))
+	if err != nil {
+		return err
+	}
+
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/asm/groups/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodPatch
+	request.Body = data
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	// Sendgrid answers updates with 201 Created
+	_, err = doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusCreated))
+	if err != nil {
+		return errors.Wrap(err, "failed to update unsubscribe group")
+	}
+
+	return resourceUnsubscribeGroupRead(d, m)
+}
+
+func resourceUnsubscribeGroupDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	request := sendgrid.GetRequest(config.APIKey, "/v3/asm/groups/"+d.Id(), sendgridAddress)
+	request.Method = http.MethodDelete
+	setOnBehalfOf(request, d.Get(keyOnBehalfOf).(string))
+
+	res, err := doRequest(request, withStatus(http.StatusNoContent), withRetry(5))
+	if err == nil || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete unsubscribe group")
+}
+
+func getUnsubscribeGroup(apiKey, id, onBehalfOf string) (*unsubscribeGroup, error) {
+	request := sendgrid.GetRequest(apiKey, "/v3/asm/groups/"+id, sendgridAddress)
+	request.Method = http.MethodGet
+	setOnBehalfOf(request, onBehalfOf)
+
+	res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query unsubscribe group")
+	}
+
+	var group unsubscribeGroup
+	err = json.Unmarshal([]byte(res.Body), &group)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal unsubscribe group query response")
+	}
+
+	return &group, nil
+}
@@ -0,0 +1,80 @@
+package sendgrid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccResourceUnsubscribeGroup(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-sg-test")
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceUnsubscribeGroupConfig(name, "Product updates"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceUnsubscribeGroupCheckSendgrid("sendgrid_unsubscribe_group.test"),
+					resource.TestCheckResourceAttr("sendgrid_unsubscribe_group.test", "name", name),
+					resource.TestCheckResourceAttr("sendgrid_unsubscribe_group.test", "is_default", "false"),
+					resource.TestCheckResourceAttrPair("sendgrid_unsubscribe_group.test", "group_id", "sendgrid_unsubscribe_group.test", "id"),
+				),
+			},
+			{
+				Config: testResourceUnsubscribeGroupConfig(name, "Monthly product updates"),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceUnsubscribeGroupCheckSendgrid("sendgrid_unsubscribe_group.test"),
+					resource.TestCheckResourceAttr("sendgrid_unsubscribe_group.test", "description", "Monthly product updates"),
+				),
+			},
+			{
+				ResourceName:      "sendgrid_unsubscribe_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceUnsubscribeGroupConfig(name, description string) string {
+	return fmt.Sprintf(`
+resource "sendgrid_unsubscribe_group" "test" {
+	name        = "%s"
+	description = "%s"
+}`, name, description)
+}
+
+func testResourceUnsubscribeGroupCheckSendgrid(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[resourceName]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+
+		instanceState := resourceState.Primary
+		if instanceState == nil {
+			return fmt.Errorf("resource has no primary instance")
+		}
+
+		apiKey := testProvider.Meta().(*Config).APIKey
+		group, err := getUnsubscribeGroup(apiKey, instanceState.ID, "")
+		if err != nil {
+			return fmt.Errorf("error reading unsubscribe group: %w", err)
+		}
+
+		if group == nil {
+			return fmt.Errorf("unsubscribe group not found")
+		}
+
+		if group.Description != instanceState.Attributes["description"] {
+			return fmt.Errorf("group.Description does not match state")
+		}
+
+		return nil
+	}
+}